@@ -0,0 +1,67 @@
+package jolt
+
+import "testing"
+
+// newBenchWorld creates a PhysicsSystem holding n dynamic spheres.
+// The benchmark is skipped if the joltc library is not available.
+func newBenchWorld(b *testing.B, n int) (*PhysicsSystem, []BodyID) {
+	b.Helper()
+	ps := newTestSystem(b, uint32(n))
+
+	bi := ps.GetBodyInterface()
+	sphere := NewSphereShape(0.5)
+	ids := make([]BodyID, n)
+	for i := range ids {
+		id, err := bi.Create(BodySpec{
+			Shape:      sphere,
+			Position:   Vec3{X: float32(i) * 2},
+			MotionType: MotionTypeDynamic,
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+		ids[i] = id
+	}
+	return ps, ids
+}
+
+const benchBodies = 5000
+
+func BenchmarkGetPositionAndRotation(b *testing.B) {
+	ps, ids := newBenchWorld(b, benchBodies)
+	bi := ps.GetBodyInterface()
+	pos := make([]Vec3, len(ids))
+	rot := make([]Quat, len(ids))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j, id := range ids {
+			pos[j] = bi.GetPosition(id)
+			rot[j] = bi.GetRotation(id)
+		}
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*len(ids)), "ns/body")
+}
+
+func BenchmarkReadTransforms(b *testing.B) {
+	ps, ids := newBenchWorld(b, benchBodies)
+	bi := ps.GetBodyInterface()
+	pos := make([]Vec3, len(ids))
+	rot := make([]Quat, len(ids))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bi.ReadTransforms(ids, pos, rot)
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*len(ids)), "ns/body")
+}
+
+func BenchmarkReadVelocities(b *testing.B) {
+	ps, ids := newBenchWorld(b, benchBodies)
+	bi := ps.GetBodyInterface()
+	linear := make([]Vec3, len(ids))
+	angular := make([]Vec3, len(ids))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bi.ReadVelocities(ids, linear, angular)
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*len(ids)), "ns/body")
+}
@@ -0,0 +1,325 @@
+package jolt
+
+// Body gives direct access to a single body while it is locked through
+// PhysicsSystem.LockBodyRead or PhysicsSystem.LockBodyWrite.
+//
+// Reading several properties through a Body costs one lock for all of them,
+// instead of one lock per BodyInterface call. The Body is only valid until
+// Unlock is called; using it afterwards panics. While a body is locked, do
+// not call PhysicsSystem.Update or BodyInterface methods, as they may wait
+// for the lock.
+//
+// Jolt does not have one mutex per body: bodies share a fixed set of mutexes
+// picked by a hash of their index, so two different bodies may share one.
+// Holding a lock while locking another body, or calling a BodyInterface
+// method for another body, can therefore deadlock, even when the bodies
+// differ. Lock one body at a time and Unlock it before locking the next.
+// Jolt's multi-body locks (BodyLockMultiRead and BodyLockMultiWrite), which
+// take the shared mutexes in a fixed order, are not wrapped by this package.
+type Body struct {
+	handle        uintptr
+	id            BodyID
+	lockInterface uintptr
+	lock          bodyLockWrite
+	writable      bool
+}
+
+// LockBodyRead locks a body for reading and returns it, or nil if bodyID
+// does not address a body. Multiple readers may hold the lock at once.
+// The caller must call Unlock on the returned Body, and must not lock any
+// other body before then; see Body for why nested locks deadlock.
+func (ps *PhysicsSystem) LockBodyRead(bodyID BodyID) *Body {
+	b := &Body{id: bodyID, lockInterface: ps.lockInterface}
+	read := (*bodyLockRead)(&b.lock)
+	jphBodyLockInterfaceLockRead(ps.lockInterface, uint32(bodyID), read)
+	if read.Body == 0 {
+		jphBodyLockInterfaceUnlockRead(ps.lockInterface, read)
+		return nil
+	}
+	b.handle = read.Body
+	return b
+}
+
+// LockBodyWrite locks a body for reading and writing and returns it, or nil
+// if bodyID does not address a body. The caller must call Unlock on the
+// returned Body, and must not lock any other body before then; see Body for
+// why nested locks deadlock.
+func (ps *PhysicsSystem) LockBodyWrite(bodyID BodyID) *Body {
+	b := &Body{id: bodyID, lockInterface: ps.lockInterface, writable: true}
+	jphBodyLockInterfaceLockWrite(ps.lockInterface, uint32(bodyID), &b.lock)
+	if b.lock.Body == 0 {
+		jphBodyLockInterfaceUnlockWrite(ps.lockInterface, &b.lock)
+		return nil
+	}
+	b.handle = b.lock.Body
+	return b
+}
+
+// Unlock releases the lock. The Body must not be used afterwards.
+// Calling Unlock more than once is a no-op, as is calling it on a Body passed
+// to a listener callback.
+func (b *Body) Unlock() {
+	if b.handle == 0 || b.lockInterface == 0 {
+		return
+	}
+	b.handle = 0
+	if b.writable {
+		jphBodyLockInterfaceUnlockWrite(b.lockInterface, &b.lock)
+	} else {
+		jphBodyLockInterfaceUnlockRead(b.lockInterface, (*bodyLockRead)(&b.lock))
+	}
+}
+
+// ptr returns the C body pointer, panicking if the lock has been released.
+func (b *Body) ptr() uintptr {
+	if b.handle == 0 {
+		panic("jolt: Body used after Unlock")
+	}
+	return b.handle
+}
+
+// writePtr returns the C body pointer, panicking if the lock has been
+// released or is a read lock.
+func (b *Body) writePtr() uintptr {
+	if !b.writable {
+		panic("jolt: Body modified through a read lock; use LockBodyWrite")
+	}
+	return b.ptr()
+}
+
+// GetID returns the body's identifier.
+func (b *Body) GetID() BodyID {
+	return b.id
+}
+
+// GetPosition returns the world position of the body.
+func (b *Body) GetPosition() Vec3 {
+	var v Vec3
+	jphBodyGetPosition(b.ptr(), &v)
+	return v
+}
+
+// GetRotation returns the rotation of the body.
+func (b *Body) GetRotation() Quat {
+	var q Quat
+	jphBodyGetRotation(b.ptr(), &q)
+	return q
+}
+
+// GetCenterOfMassPosition returns the world position of the body's center of mass.
+func (b *Body) GetCenterOfMassPosition() Vec3 {
+	var v Vec3
+	jphBodyGetCenterOfMassPosition(b.ptr(), &v)
+	return v
+}
+
+// GetLinearVelocity returns the linear velocity (m/s) of the body.
+func (b *Body) GetLinearVelocity() Vec3 {
+	var v Vec3
+	jphBodyGetLinearVelocity(b.ptr(), &v)
+	return v
+}
+
+// SetLinearVelocity sets the linear velocity (m/s). Requires a write lock.
+func (b *Body) SetLinearVelocity(v Vec3) {
+	jphBodySetLinearVelocity(b.writePtr(), &v)
+}
+
+// GetAngularVelocity returns the angular velocity (rad/s) of the body.
+func (b *Body) GetAngularVelocity() Vec3 {
+	var v Vec3
+	jphBodyGetAngularVelocity(b.ptr(), &v)
+	return v
+}
+
+// SetAngularVelocity sets the angular velocity (rad/s). Requires a write lock.
+func (b *Body) SetAngularVelocity(v Vec3) {
+	jphBodySetAngularVelocity(b.writePtr(), &v)
+}
+
+// AddForce adds a force (in Newtons) at the center of mass for the next
+// step. Requires a write lock. Unlike BodyInterface.AddForce, it does not
+// wake a sleeping body.
+func (b *Body) AddForce(force Vec3) {
+	jphBodyAddForce(b.writePtr(), &force)
+}
+
+// AddTorque adds a torque (in Newton meters) for the next step. Requires a
+// write lock. It does not wake a sleeping body.
+func (b *Body) AddTorque(torque Vec3) {
+	jphBodyAddTorque(b.writePtr(), &torque)
+}
+
+// AddImpulse applies an impulse at the center of mass. Requires a write lock.
+// It does not wake a sleeping body.
+func (b *Body) AddImpulse(impulse Vec3) {
+	jphBodyAddImpulse(b.writePtr(), &impulse)
+}
+
+// GetShape returns the body's shape. Like BodyInterface.GetShape, the returned
+// Shape holds its own reference and must be released with Destroy.
+func (b *Body) GetShape() *Shape {
+	h := jphBodyGetShape(b.ptr())
+	if h == 0 {
+		return nil
+	}
+	jphShapeAddRef(h)
+	return &Shape{handle: h}
+}
+
+// GetMotionType returns how the body moves in the simulation.
+func (b *Body) GetMotionType() MotionType {
+	return MotionType(jphBodyGetMotionType(b.ptr()))
+}
+
+// GetMotionProperties returns the body's motion properties, or nil for static
+// bodies. The result is only valid while the body is locked.
+func (b *Body) GetMotionProperties() *MotionProperties {
+	h := jphBodyGetMotionProperties(b.ptr())
+	if h == 0 {
+		return nil
+	}
+	return &MotionProperties{handle: h, body: b}
+}
+
+// GetObjectLayer returns the collision layer of the body.
+func (b *Body) GetObjectLayer() ObjectLayer {
+	return ObjectLayer(jphBodyGetObjectLayer(b.ptr()))
+}
+
+// GetFriction returns the friction coefficient of the body.
+func (b *Body) GetFriction() float32 {
+	return jphBodyGetFriction(b.ptr())
+}
+
+// GetRestitution returns the restitution (bounciness) of the body.
+func (b *Body) GetRestitution() float32 {
+	return jphBodyGetRestitution(b.ptr())
+}
+
+// GetUserData returns the Go value attached with BodyInterface.SetUserData.
+func (b *Body) GetUserData() any {
+	return userDataValue(jphBodyGetUserData(b.ptr()))
+}
+
+// IsSensor returns whether the body is a sensor, which detects contacts
+// without responding to them.
+func (b *Body) IsSensor() bool {
+	return jphBodyIsSensor(b.ptr())
+}
+
+// SetIsSensor turns the body into a sensor or back. Requires a write lock.
+func (b *Body) SetIsSensor(sensor bool) {
+	jphBodySetIsSensor(b.writePtr(), sensor)
+}
+
+// IsActive returns whether the body is awake.
+func (b *Body) IsActive() bool {
+	return jphBodyIsActive(b.ptr())
+}
+
+// GetBodyType returns whether this is a rigid or a soft body.
+func (b *Body) GetBodyType() BodyType {
+	return BodyType(jphBodyGetBodyType(b.ptr()))
+}
+
+// IsStatic returns whether the body has MotionTypeStatic.
+func (b *Body) IsStatic() bool {
+	return jphBodyIsStatic(b.ptr())
+}
+
+// IsKinematic returns whether the body has MotionTypeKinematic.
+func (b *Body) IsKinematic() bool {
+	return jphBodyIsKinematic(b.ptr())
+}
+
+// IsDynamic returns whether the body has MotionTypeDynamic.
+func (b *Body) IsDynamic() bool {
+	return jphBodyIsDynamic(b.ptr())
+}
+
+// GetAllowSleeping returns whether the body is allowed to go to sleep.
+func (b *Body) GetAllowSleeping() bool {
+	return jphBodyGetAllowSleeping(b.ptr())
+}
+
+// SetAllowSleeping controls whether the body may go to sleep. Requires a
+// write lock.
+func (b *Body) SetAllowSleeping(allow bool) {
+	jphBodySetAllowSleeping(b.writePtr(), allow)
+}
+
+// ResetSleepTimer restarts the time the body must be still before it goes
+// to sleep. Requires a write lock.
+func (b *Body) ResetSleepTimer() {
+	jphBodyResetSleepTimer(b.writePtr())
+}
+
+// GetWorldSpaceBounds returns the world-space bounding box of the body.
+func (b *Body) GetWorldSpaceBounds() AABox {
+	var box AABox
+	jphBodyGetWorldSpaceBounds(b.ptr(), &box)
+	return box
+}
+
+// MotionProperties holds the mass and velocity state of a non-static body.
+// It is obtained from Body.GetMotionProperties and shares the Body's lock.
+type MotionProperties struct {
+	handle uintptr
+	body   *Body
+}
+
+// ptr returns the C motion properties pointer, panicking if the owning
+// body's lock has been released.
+func (mp *MotionProperties) ptr() uintptr {
+	mp.body.ptr()
+	return mp.handle
+}
+
+// GetInverseMass returns the inverse mass (1/kg).
+func (mp *MotionProperties) GetInverseMass() float32 {
+	return jphMotionPropertiesGetInverseMassUnchecked(mp.ptr())
+}
+
+// GetInverseInertiaDiagonal returns the diagonal of the local-space inverse
+// inertia tensor, in the frame given by GetInertiaRotation.
+func (mp *MotionProperties) GetInverseInertiaDiagonal() Vec3 {
+	var v Vec3
+	jphMotionPropertiesGetInverseInertiaDiagonal(mp.ptr(), &v)
+	return v
+}
+
+// GetInertiaRotation returns the rotation of the principal axes of inertia
+// relative to the body.
+func (mp *MotionProperties) GetInertiaRotation() Quat {
+	var q Quat
+	jphMotionPropertiesGetInertiaRotation(mp.ptr(), &q)
+	return q
+}
+
+// GetLinearDamping returns the linear damping factor.
+func (mp *MotionProperties) GetLinearDamping() float32 {
+	return jphMotionPropertiesGetLinearDamping(mp.ptr())
+}
+
+// SetLinearDamping sets the linear damping factor. Requires a write lock.
+func (mp *MotionProperties) SetLinearDamping(damping float32) {
+	mp.body.writePtr()
+	jphMotionPropertiesSetLinearDamping(mp.handle, damping)
+}
+
+// GetAngularDamping returns the angular damping factor.
+func (mp *MotionProperties) GetAngularDamping() float32 {
+	return jphMotionPropertiesGetAngularDamping(mp.ptr())
+}
+
+// SetAngularDamping sets the angular damping factor. Requires a write lock.
+func (mp *MotionProperties) SetAngularDamping(damping float32) {
+	mp.body.writePtr()
+	jphMotionPropertiesSetAngularDamping(mp.handle, damping)
+}
+
+// GetAllowedDOFs returns the degrees of freedom the body may move in.
+func (mp *MotionProperties) GetAllowedDOFs() AllowedDOFs {
+	return AllowedDOFs(jphMotionPropertiesGetAllowedDOFs(mp.ptr()))
+}
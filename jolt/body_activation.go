@@ -0,0 +1,138 @@
+package jolt
+
+import (
+	"sync"
+
+	"github.com/ebitengine/purego"
+)
+
+// BodyActivationListener is notified when bodies wake up or go to sleep.
+// Install it with PhysicsSystem.SetBodyActivationListener.
+//
+// The methods are called from Jolt's worker threads during
+// PhysicsSystem.Update, and from the goroutine that activates or deactivates
+// a body through the BodyInterface. They must be safe for concurrent use and
+// must not lock bodies or call BodyInterface methods, since Jolt holds
+// internal locks while calling them. userData is the value attached with
+// BodyInterface.SetUserData, or nil.
+type BodyActivationListener interface {
+	OnBodyActivated(bodyID BodyID, userData any)
+	OnBodyDeactivated(bodyID BodyID, userData any)
+}
+
+// SetBodyActivationListener installs l to receive activation notifications,
+// replacing any previous listener. Pass nil to remove the listener. It must
+// not be called during Update.
+func (ps *PhysicsSystem) SetBodyActivationListener(l BodyActivationListener) {
+	old := ps.activationListener
+	ps.activationListener = nativeListener{}
+	if l != nil {
+		registerBodyActivationListenerProcs()
+		ps.activationListener.slot = bodyActivationListeners.add(l)
+		ps.activationListener.handle = jphBodyActivationListenerCreate(uintptr(ps.activationListener.slot))
+	}
+	jphPhysicsSystemSetBodyActivationListener(ps.handle, ps.activationListener.handle)
+	old.release(jphBodyActivationListenerDestroy, bodyActivationListeners.remove)
+}
+
+// BodyActivationEvent is an activation change recorded by
+// BodyActivationEvents.
+type BodyActivationEvent struct {
+	BodyID BodyID
+	// Active is true when the body woke up and false when it went to sleep.
+	Active bool
+	// UserData is the value attached with BodyInterface.SetUserData, or nil.
+	UserData any
+}
+
+// BodyActivationEvents is a BodyActivationListener that records activation
+// changes into a fixed-size buffer, to be drained on the caller's goroutine
+// after PhysicsSystem.Update returns. Like ContactEvents, recording is
+// lock-free and events beyond the capacity are dropped.
+type BodyActivationEvents struct {
+	buf eventBuffer[BodyActivationEvent]
+}
+
+// NewBodyActivationEvents creates a collector that buffers up to capacity
+// events between drains.
+func NewBodyActivationEvents(capacity int) *BodyActivationEvents {
+	return &BodyActivationEvents{buf: newEventBuffer[BodyActivationEvent](capacity)}
+}
+
+// Len returns the number of buffered events.
+func (c *BodyActivationEvents) Len() int {
+	return c.buf.len()
+}
+
+// Dropped returns the total number of events lost because the buffer was
+// full.
+func (c *BodyActivationEvents) Dropped() uint64 {
+	return c.buf.dropped.Load()
+}
+
+// Drain appends the buffered events to dst in the order they were recorded,
+// empties the buffer and returns the extended slice. It must not be called
+// during Update.
+func (c *BodyActivationEvents) Drain(dst []BodyActivationEvent) []BodyActivationEvent {
+	return c.buf.drain(dst)
+}
+
+// DrainTo sends the buffered events to ch, blocking while ch is full, and
+// empties the buffer. It must not be called during Update.
+func (c *BodyActivationEvents) DrainTo(ch chan<- BodyActivationEvent) {
+	c.buf.drainTo(ch)
+}
+
+// OnBodyActivated records an event with Active set.
+func (c *BodyActivationEvents) OnBodyActivated(bodyID BodyID, userData any) {
+	c.buf.record(BodyActivationEvent{BodyID: bodyID, Active: true, UserData: userData})
+}
+
+// OnBodyDeactivated records an event with Active cleared.
+func (c *BodyActivationEvents) OnBodyDeactivated(bodyID BodyID, userData any) {
+	c.buf.record(BodyActivationEvent{BodyID: bodyID, UserData: userData})
+}
+
+// bodyActivationListenerProcs mirrors the C struct
+// JPH_BodyActivationListener_Procs.
+type bodyActivationListenerProcs struct {
+	OnBodyActivated   uintptr
+	OnBodyDeactivated uintptr
+}
+
+var bodyActivationListenerProcsOnce sync.Once
+
+// registerBodyActivationListenerProcs installs the Go callbacks shared by all
+// activation listeners, as registerContactListenerProcs does for contacts.
+func registerBodyActivationListenerProcs() {
+	bodyActivationListenerProcsOnce.Do(func() {
+		procs := bodyActivationListenerProcs{
+			OnBodyActivated:   purego.NewCallback(onBodyActivated),
+			OnBodyDeactivated: purego.NewCallback(onBodyDeactivated),
+		}
+		jphBodyActivationListenerSetProcs(&procs)
+	})
+}
+
+// bodyActivationListeners holds the Go listeners of installed body
+// activation listeners.
+var bodyActivationListeners slotTable[BodyActivationListener]
+
+// bodyActivationListenerFor returns the listener registered under userData.
+func bodyActivationListenerFor(userData uintptr) BodyActivationListener {
+	return bodyActivationListeners.get(userData)
+}
+
+func onBodyActivated(userData uintptr, bodyID uint32, bodyUserData uint64) uintptr {
+	if l := bodyActivationListenerFor(userData); l != nil {
+		l.OnBodyActivated(BodyID(bodyID), userDataValue(bodyUserData))
+	}
+	return 0
+}
+
+func onBodyDeactivated(userData uintptr, bodyID uint32, bodyUserData uint64) uintptr {
+	if l := bodyActivationListenerFor(userData); l != nil {
+		l.OnBodyDeactivated(BodyID(bodyID), userDataValue(bodyUserData))
+	}
+	return 0
+}
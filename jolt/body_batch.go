@@ -0,0 +1,53 @@
+package jolt
+
+// AddBodiesBatch is a set of bodies that has been prepared for insertion into
+// the broad phase but not yet added to the simulation. It is returned by
+// BodyInterface.PrepareAddBodies and must be completed by calling exactly one
+// of Finalize or Abort.
+type AddBodiesBatch struct {
+	bi    *BodyInterface
+	ids   []BodyID
+	state uintptr
+}
+
+// AddBodies adds a batch of bodies created with CreateBody (or removed with
+// RemoveBody) to the physics world. This is much faster than calling AddBody
+// for each body and produces a better broad-phase tree.
+//
+// The order of ids may be changed by this call.
+func (bi *BodyInterface) AddBodies(ids []BodyID, activation Activation) {
+	bi.PrepareAddBodies(ids).Finalize(activation)
+}
+
+// PrepareAddBodies builds the broad-phase structures for a batch of bodies
+// without touching the simulation. It is safe to call from multiple worker
+// goroutines at once, for example while streaming in a level, as long as
+// each call receives its own ids slice.
+//
+// The ids slice is sorted in place and must not be modified until the
+// returned batch is finalized or aborted.
+func (bi *BodyInterface) PrepareAddBodies(ids []BodyID) *AddBodiesBatch {
+	batch := &AddBodiesBatch{bi: bi, ids: ids}
+	if len(ids) > 0 {
+		batch.state = jphBodyInterfaceAddBodiesPrepare(bi.handle, &ids[0], int32(len(ids)))
+	}
+	return batch
+}
+
+// Finalize adds the prepared bodies to the simulation. It must not be called
+// concurrently with PhysicsSystem.Update.
+func (b *AddBodiesBatch) Finalize(activation Activation) {
+	if len(b.ids) > 0 {
+		jphBodyInterfaceAddBodiesFinalize(b.bi.handle, &b.ids[0], int32(len(b.ids)), b.state, int32(activation))
+	}
+	b.ids = nil
+}
+
+// Abort discards a prepared batch without adding its bodies. The bodies
+// remain created and can be prepared again or destroyed.
+func (b *AddBodiesBatch) Abort() {
+	if len(b.ids) > 0 {
+		jphBodyInterfaceAddBodiesAbort(b.bi.handle, &b.ids[0], int32(len(b.ids)), b.state)
+	}
+	b.ids = nil
+}
@@ -0,0 +1,495 @@
+package jolt
+
+import "fmt"
+
+// CheckedBodyInterface is a BodyInterface that validates every BodyID before
+// using it, returning ErrInvalidBody instead of silently addressing the wrong
+// body or none at all. Obtain one with BodyInterface.Checked.
+//
+// It covers every BodyInterface method that addresses a single body. Body
+// creation (CreateBody, TryCreateAndAddBody, Create), batch insertion
+// (AddBodies, PrepareAddBodies) and bulk readback (ReadTransforms,
+// ReadVelocities) are only available through Unchecked.
+//
+// Checking costs an extra body lock per call. It catches IDs kept after their
+// body was destroyed, but is not a substitute for synchronization: a body
+// destroyed by another goroutine between the check and the call is not
+// detected.
+type CheckedBodyInterface struct {
+	bi *BodyInterface
+}
+
+// Checked returns a view of bi that validates body IDs.
+func (bi *BodyInterface) Checked() *CheckedBodyInterface {
+	return &CheckedBodyInterface{bi: bi}
+}
+
+// Unchecked returns the underlying BodyInterface.
+func (c *CheckedBodyInterface) Unchecked() *BodyInterface {
+	return c.bi
+}
+
+// bodyState reports whether bodyID addresses a live body, and whether that
+// body is currently added to the simulation.
+func (bi *BodyInterface) bodyState(bodyID BodyID) (exists, added bool) {
+	if bodyID.IsInvalid() {
+		return false, false
+	}
+	var lock bodyLockRead
+	jphBodyLockInterfaceLockRead(bi.lockInterface, uint32(bodyID), &lock)
+	defer jphBodyLockInterfaceUnlockRead(bi.lockInterface, &lock)
+	if lock.Body == 0 {
+		return false, false
+	}
+	return true, jphBodyIsInBroadPhase(lock.Body)
+}
+
+// ValidateBodyID returns ErrInvalidBody if bodyID is BodyIDInvalid or does
+// not address a live body (for example because the body was destroyed and
+// its index reused). The body does not need to be added.
+func (bi *BodyInterface) ValidateBodyID(bodyID BodyID) error {
+	if exists, _ := bi.bodyState(bodyID); !exists {
+		return fmt.Errorf("%w: %#x", ErrInvalidBody, uint32(bodyID))
+	}
+	return nil
+}
+
+// added returns ErrInvalidBody unless bodyID addresses a body that is in
+// the simulation.
+func (c *CheckedBodyInterface) added(bodyID BodyID) error {
+	exists, added := c.bi.bodyState(bodyID)
+	if !exists {
+		return fmt.Errorf("%w: %#x", ErrInvalidBody, uint32(bodyID))
+	}
+	if !added {
+		return fmt.Errorf("%w: %#x is not added", ErrInvalidBody, uint32(bodyID))
+	}
+	return nil
+}
+
+// removed returns ErrInvalidBody unless bodyID addresses a body that exists
+// but is not in the simulation.
+func (c *CheckedBodyInterface) removed(bodyID BodyID) error {
+	exists, added := c.bi.bodyState(bodyID)
+	if !exists {
+		return fmt.Errorf("%w: %#x", ErrInvalidBody, uint32(bodyID))
+	}
+	if added {
+		return fmt.Errorf("%w: %#x is already added", ErrInvalidBody, uint32(bodyID))
+	}
+	return nil
+}
+
+// AddBody adds a created or removed body to the simulation.
+func (c *CheckedBodyInterface) AddBody(bodyID BodyID, activation Activation) error {
+	if err := c.removed(bodyID); err != nil {
+		return err
+	}
+	c.bi.AddBody(bodyID, activation)
+	return nil
+}
+
+// RemoveBody removes an added body from the simulation without destroying it.
+func (c *CheckedBodyInterface) RemoveBody(bodyID BodyID) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.RemoveBody(bodyID)
+	return nil
+}
+
+// RemoveAndDestroyBody removes an added body from the simulation and destroys it.
+func (c *CheckedBodyInterface) RemoveAndDestroyBody(bodyID BodyID) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.RemoveAndDestroyBody(bodyID)
+	return nil
+}
+
+// DestroyBody destroys a body that is not in the simulation.
+func (c *CheckedBodyInterface) DestroyBody(bodyID BodyID) error {
+	if err := c.removed(bodyID); err != nil {
+		return err
+	}
+	c.bi.DestroyBody(bodyID)
+	return nil
+}
+
+// ActivateBody wakes a sleeping body.
+func (c *CheckedBodyInterface) ActivateBody(bodyID BodyID) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.ActivateBody(bodyID)
+	return nil
+}
+
+// DeactivateBody puts a body to sleep.
+func (c *CheckedBodyInterface) DeactivateBody(bodyID BodyID) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.DeactivateBody(bodyID)
+	return nil
+}
+
+// IsActive returns whether a body is awake.
+func (c *CheckedBodyInterface) IsActive(bodyID BodyID) (bool, error) {
+	if err := c.added(bodyID); err != nil {
+		return false, err
+	}
+	return c.bi.IsActive(bodyID), nil
+}
+
+// IsAdded returns whether a body is in the simulation. The body only needs
+// to exist.
+func (c *CheckedBodyInterface) IsAdded(bodyID BodyID) (bool, error) {
+	exists, added := c.bi.bodyState(bodyID)
+	if !exists {
+		return false, fmt.Errorf("%w: %#x", ErrInvalidBody, uint32(bodyID))
+	}
+	return added, nil
+}
+
+// SetPosition sets the world position of a body.
+func (c *CheckedBodyInterface) SetPosition(bodyID BodyID, position Vec3, activation Activation) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.SetPosition(bodyID, position, activation)
+	return nil
+}
+
+// GetPosition returns the world position of a body.
+func (c *CheckedBodyInterface) GetPosition(bodyID BodyID) (Vec3, error) {
+	if err := c.added(bodyID); err != nil {
+		return Vec3{}, err
+	}
+	return c.bi.GetPosition(bodyID), nil
+}
+
+// SetRotation sets the rotation of a body.
+func (c *CheckedBodyInterface) SetRotation(bodyID BodyID, rotation Quat, activation Activation) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.SetRotation(bodyID, rotation, activation)
+	return nil
+}
+
+// GetRotation returns the rotation of a body.
+func (c *CheckedBodyInterface) GetRotation(bodyID BodyID) (Quat, error) {
+	if err := c.added(bodyID); err != nil {
+		return Quat{}, err
+	}
+	return c.bi.GetRotation(bodyID), nil
+}
+
+// SetPositionAndRotation sets the world position and rotation of a body.
+func (c *CheckedBodyInterface) SetPositionAndRotation(bodyID BodyID, position Vec3, rotation Quat, activation Activation) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.SetPositionAndRotation(bodyID, position, rotation, activation)
+	return nil
+}
+
+// GetCenterOfMassPosition returns the world position of the center of mass of a body.
+func (c *CheckedBodyInterface) GetCenterOfMassPosition(bodyID BodyID) (Vec3, error) {
+	if err := c.added(bodyID); err != nil {
+		return Vec3{}, err
+	}
+	return c.bi.GetCenterOfMassPosition(bodyID), nil
+}
+
+// SetPositionRotationAndVelocity sets the position, rotation, and linear and angular
+// velocity of a body.
+func (c *CheckedBodyInterface) SetPositionRotationAndVelocity(bodyID BodyID, position Vec3, rotation Quat, linearVelocity, angularVelocity Vec3) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.SetPositionRotationAndVelocity(bodyID, position, rotation, linearVelocity, angularVelocity)
+	return nil
+}
+
+// SetLinearVelocity sets the linear velocity of a body.
+func (c *CheckedBodyInterface) SetLinearVelocity(bodyID BodyID, velocity Vec3) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.SetLinearVelocity(bodyID, velocity)
+	return nil
+}
+
+// GetLinearVelocity returns the linear velocity of a body.
+func (c *CheckedBodyInterface) GetLinearVelocity(bodyID BodyID) (Vec3, error) {
+	if err := c.added(bodyID); err != nil {
+		return Vec3{}, err
+	}
+	return c.bi.GetLinearVelocity(bodyID), nil
+}
+
+// SetAngularVelocity sets the angular velocity of a body.
+func (c *CheckedBodyInterface) SetAngularVelocity(bodyID BodyID, angularVelocity Vec3) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.SetAngularVelocity(bodyID, angularVelocity)
+	return nil
+}
+
+// GetAngularVelocity returns the angular velocity of a body.
+func (c *CheckedBodyInterface) GetAngularVelocity(bodyID BodyID) (Vec3, error) {
+	if err := c.added(bodyID); err != nil {
+		return Vec3{}, err
+	}
+	return c.bi.GetAngularVelocity(bodyID), nil
+}
+
+// AddLinearVelocity adds to the linear velocity of a body.
+func (c *CheckedBodyInterface) AddLinearVelocity(bodyID BodyID, velocity Vec3) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.AddLinearVelocity(bodyID, velocity)
+	return nil
+}
+
+// SetLinearAndAngularVelocity sets the linear and angular velocity of a body.
+func (c *CheckedBodyInterface) SetLinearAndAngularVelocity(bodyID BodyID, linearVelocity, angularVelocity Vec3) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.SetLinearAndAngularVelocity(bodyID, linearVelocity, angularVelocity)
+	return nil
+}
+
+// GetPointVelocity returns the velocity of a world-space point attached to a
+// body.
+func (c *CheckedBodyInterface) GetPointVelocity(bodyID BodyID, point Vec3) (Vec3, error) {
+	if err := c.added(bodyID); err != nil {
+		return Vec3{}, err
+	}
+	return c.bi.GetPointVelocity(bodyID, point), nil
+}
+
+// AddForce adds a force at the center of mass of a body.
+func (c *CheckedBodyInterface) AddForce(bodyID BodyID, force Vec3) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.AddForce(bodyID, force)
+	return nil
+}
+
+// AddForceAtPoint adds a force at a world-space point on a body.
+func (c *CheckedBodyInterface) AddForceAtPoint(bodyID BodyID, force Vec3, point Vec3) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.AddForceAtPoint(bodyID, force, point)
+	return nil
+}
+
+// AddTorque adds a torque to a body.
+func (c *CheckedBodyInterface) AddTorque(bodyID BodyID, torque Vec3) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.AddTorque(bodyID, torque)
+	return nil
+}
+
+// AddImpulse applies an impulse at the center of mass of a body.
+func (c *CheckedBodyInterface) AddImpulse(bodyID BodyID, impulse Vec3) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.AddImpulse(bodyID, impulse)
+	return nil
+}
+
+// AddImpulseAtPoint applies an impulse at a world-space point on a body.
+func (c *CheckedBodyInterface) AddImpulseAtPoint(bodyID BodyID, impulse Vec3, point Vec3) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.AddImpulseAtPoint(bodyID, impulse, point)
+	return nil
+}
+
+// AddAngularImpulse applies an angular impulse to a body.
+func (c *CheckedBodyInterface) AddAngularImpulse(bodyID BodyID, angularImpulse Vec3) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.AddAngularImpulse(bodyID, angularImpulse)
+	return nil
+}
+
+// SetFriction sets the friction coefficient of a body.
+func (c *CheckedBodyInterface) SetFriction(bodyID BodyID, friction float32) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.SetFriction(bodyID, friction)
+	return nil
+}
+
+// GetFriction returns the friction coefficient of a body.
+func (c *CheckedBodyInterface) GetFriction(bodyID BodyID) (float32, error) {
+	if err := c.added(bodyID); err != nil {
+		return 0, err
+	}
+	return c.bi.GetFriction(bodyID), nil
+}
+
+// SetRestitution sets the restitution of a body.
+func (c *CheckedBodyInterface) SetRestitution(bodyID BodyID, restitution float32) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.SetRestitution(bodyID, restitution)
+	return nil
+}
+
+// GetRestitution returns the restitution of a body.
+func (c *CheckedBodyInterface) GetRestitution(bodyID BodyID) (float32, error) {
+	if err := c.added(bodyID); err != nil {
+		return 0, err
+	}
+	return c.bi.GetRestitution(bodyID), nil
+}
+
+// SetGravityFactor sets the factor applied to gravity for a body.
+func (c *CheckedBodyInterface) SetGravityFactor(bodyID BodyID, factor float32) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.SetGravityFactor(bodyID, factor)
+	return nil
+}
+
+// GetGravityFactor returns the factor applied to gravity for a body.
+func (c *CheckedBodyInterface) GetGravityFactor(bodyID BodyID) (float32, error) {
+	if err := c.added(bodyID); err != nil {
+		return 0, err
+	}
+	return c.bi.GetGravityFactor(bodyID), nil
+}
+
+// GetInverseMass returns the inverse mass (1/kg) of a body.
+func (c *CheckedBodyInterface) GetInverseMass(bodyID BodyID) (float32, error) {
+	if err := c.added(bodyID); err != nil {
+		return 0, err
+	}
+	return c.bi.GetInverseMass(bodyID), nil
+}
+
+// GetInverseInertia returns the world-space inverse inertia tensor of a body.
+func (c *CheckedBodyInterface) GetInverseInertia(bodyID BodyID) (Matrix4x4, error) {
+	if err := c.added(bodyID); err != nil {
+		return Matrix4x4{}, err
+	}
+	return c.bi.GetInverseInertia(bodyID), nil
+}
+
+// MoveKinematic moves a kinematic body towards a target pose over deltaTime.
+func (c *CheckedBodyInterface) MoveKinematic(bodyID BodyID, targetPosition Vec3, targetRotation Quat, deltaTime float32) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.MoveKinematic(bodyID, targetPosition, targetRotation, deltaTime)
+	return nil
+}
+
+// SetMotionType changes the motion type of a body.
+func (c *CheckedBodyInterface) SetMotionType(bodyID BodyID, motionType MotionType, activation Activation) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.SetMotionType(bodyID, motionType, activation)
+	return nil
+}
+
+// GetMotionType returns the motion type of a body.
+func (c *CheckedBodyInterface) GetMotionType(bodyID BodyID) (MotionType, error) {
+	if err := c.added(bodyID); err != nil {
+		return 0, err
+	}
+	return c.bi.GetMotionType(bodyID), nil
+}
+
+// SetObjectLayer moves a body to another collision layer.
+func (c *CheckedBodyInterface) SetObjectLayer(bodyID BodyID, layer ObjectLayer) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.SetObjectLayer(bodyID, layer)
+	return nil
+}
+
+// GetObjectLayer returns the collision layer of a body.
+func (c *CheckedBodyInterface) GetObjectLayer(bodyID BodyID) (ObjectLayer, error) {
+	if err := c.added(bodyID); err != nil {
+		return 0, err
+	}
+	return c.bi.GetObjectLayer(bodyID), nil
+}
+
+// SetCollisionGroup sets the collision group of a body.
+func (c *CheckedBodyInterface) SetCollisionGroup(bodyID BodyID, group CollisionGroup) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.SetCollisionGroup(bodyID, group)
+	return nil
+}
+
+// GetCollisionGroup returns the collision group of a body.
+func (c *CheckedBodyInterface) GetCollisionGroup(bodyID BodyID) (CollisionGroup, error) {
+	if err := c.added(bodyID); err != nil {
+		return CollisionGroup{}, err
+	}
+	return c.bi.GetCollisionGroup(bodyID), nil
+}
+
+// GetShape returns the shape of a body. The caller must call Destroy on
+// it.
+func (c *CheckedBodyInterface) GetShape(bodyID BodyID) (*Shape, error) {
+	if err := c.added(bodyID); err != nil {
+		return nil, err
+	}
+	return c.bi.GetShape(bodyID), nil
+}
+
+// SetShape replaces the shape of a body. It returns ErrShapeRequired if shape
+// is nil.
+func (c *CheckedBodyInterface) SetShape(bodyID BodyID, shape *Shape, updateMassProperties bool, activation Activation) error {
+	if shape == nil || shape.handle == 0 {
+		return ErrShapeRequired
+	}
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.SetShape(bodyID, shape, updateMassProperties, activation)
+	return nil
+}
+
+// SetUserData attaches a Go value to a body.
+func (c *CheckedBodyInterface) SetUserData(bodyID BodyID, value any) error {
+	if err := c.added(bodyID); err != nil {
+		return err
+	}
+	c.bi.SetUserData(bodyID, value)
+	return nil
+}
+
+// GetUserData returns the Go value attached to a body.
+func (c *CheckedBodyInterface) GetUserData(bodyID BodyID) (any, error) {
+	if err := c.added(bodyID); err != nil {
+		return nil, err
+	}
+	return c.bi.GetUserData(bodyID), nil
+}
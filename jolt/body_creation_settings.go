@@ -43,6 +43,18 @@ func (bcs *BodyCreationSettings) GetLinearVelocity() Vec3 {
 	return v
 }
 
+// SetAngularVelocity sets the initial angular velocity (rad/s).
+func (bcs *BodyCreationSettings) SetAngularVelocity(v Vec3) {
+	jphBodyCreationSettingsSetAngularVelocity(bcs.handle, &v)
+}
+
+// GetAngularVelocity returns the initial angular velocity (rad/s).
+func (bcs *BodyCreationSettings) GetAngularVelocity() Vec3 {
+	var v Vec3
+	jphBodyCreationSettingsGetAngularVelocity(bcs.handle, &v)
+	return v
+}
+
 // SetFriction sets the friction coefficient.
 func (bcs *BodyCreationSettings) SetFriction(v float32) {
 	jphBodyCreationSettingsSetFriction(bcs.handle, v)
@@ -92,3 +104,43 @@ func (bcs *BodyCreationSettings) SetMotionQuality(v MotionQuality) {
 func (bcs *BodyCreationSettings) GetMotionQuality() MotionQuality {
 	return MotionQuality(jphBodyCreationSettingsGetMotionQuality(bcs.handle))
 }
+
+// SetCollisionGroup sets the collision group used for group filtering.
+// The body created from these settings keeps its own reference to the
+// group's GroupFilter.
+func (bcs *BodyCreationSettings) SetCollisionGroup(group CollisionGroup) {
+	c := group.toC()
+	jphBodyCreationSettingsSetCollisionGroup(bcs.handle, &c)
+}
+
+// GetCollisionGroup returns the collision group.
+func (bcs *BodyCreationSettings) GetCollisionGroup() CollisionGroup {
+	var c collisionGroup
+	jphBodyCreationSettingsGetCollisionGroup(bcs.handle, &c)
+	return c.fromC()
+}
+
+// SetOverrideMassProperties selects how the body's mass and inertia are
+// determined. Values other than OverrideMassPropertiesCalculateMassAndInertia
+// use the properties set with SetMassPropertiesOverride.
+func (bcs *BodyCreationSettings) SetOverrideMassProperties(v OverrideMassProperties) {
+	jphBodyCreationSettingsSetOverrideMassProperties(bcs.handle, int32(v))
+}
+
+// GetOverrideMassProperties returns the mass properties override mode.
+func (bcs *BodyCreationSettings) GetOverrideMassProperties() OverrideMassProperties {
+	return OverrideMassProperties(jphBodyCreationSettingsGetOverrideMassProperties(bcs.handle))
+}
+
+// SetMassPropertiesOverride sets the mass (and optionally inertia) used when
+// the override mode is not OverrideMassPropertiesCalculateMassAndInertia.
+func (bcs *BodyCreationSettings) SetMassPropertiesOverride(mp MassProperties) {
+	jphBodyCreationSettingsSetMassPropertiesOverride(bcs.handle, &mp)
+}
+
+// GetMassPropertiesOverride returns the mass properties override.
+func (bcs *BodyCreationSettings) GetMassPropertiesOverride() MassProperties {
+	var mp MassProperties
+	jphBodyCreationSettingsGetMassPropertiesOverride(bcs.handle, &mp)
+	return mp
+}
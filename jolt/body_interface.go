@@ -7,27 +7,68 @@ package jolt
 // PhysicsSystem and remains valid for the PhysicsSystem's lifetime.
 type BodyInterface struct {
 	handle uintptr
+
+	// lockInterface is the PhysicsSystem's locking body lock interface, used
+	// for reads that joltc only exposes on the body itself.
+	lockInterface uintptr
 }
 
 // CreateAndAddBody creates a new body from the given settings and immediately
-// adds it to the physics world. Returns the BodyID of the new body.
+// adds it to the physics world. Returns the BodyID of the new body, or
+// BodyIDInvalid if the PhysicsSystem is full. Use TryCreateAndAddBody to get
+// an error instead.
 func (bi *BodyInterface) CreateAndAddBody(settings *BodyCreationSettings, activation Activation) BodyID {
-	id := jphBodyInterfaceCreateAndAddBody(bi.handle, settings.handle, int32(activation))
-	return BodyID(id)
+	return BodyID(jphBodyInterfaceCreateAndAddBody(bi.handle, settings.handle, int32(activation)))
+}
+
+// TryCreateAndAddBody is like CreateAndAddBody but returns
+// ErrBodyLimitReached if the PhysicsSystem is full.
+func (bi *BodyInterface) TryCreateAndAddBody(settings *BodyCreationSettings, activation Activation) (BodyID, error) {
+	return createdBodyID(jphBodyInterfaceCreateAndAddBody(bi.handle, settings.handle, int32(activation)))
+}
+
+// createdBodyID converts the ID returned by a joltc create function, which
+// is invalid when the body limit has been reached.
+func createdBodyID(id uint32) (BodyID, error) {
+	if BodyID(id).IsInvalid() {
+		return BodyIDInvalid, ErrBodyLimitReached
+	}
+	return BodyID(id), nil
+}
+
+// CreateBody creates a new body from the given settings without adding it
+// to the physics world. Add it later with AddBody; a body that is never
+// added must still be released with DestroyBody.
+// Returns ErrBodyLimitReached if the PhysicsSystem is full.
+func (bi *BodyInterface) CreateBody(settings *BodyCreationSettings) (BodyID, error) {
+	body := jphBodyInterfaceCreateBody(bi.handle, settings.handle)
+	if body == 0 {
+		return BodyIDInvalid, ErrBodyLimitReached
+	}
+	return BodyID(jphBodyGetID(body)), nil
+}
+
+// AddBody adds a body created with CreateBody, or previously removed with
+// RemoveBody, to the physics world.
+func (bi *BodyInterface) AddBody(bodyID BodyID, activation Activation) {
+	jphBodyInterfaceAddBody(bi.handle, uint32(bodyID), int32(activation))
 }
 
 // RemoveAndDestroyBody removes a body from the simulation and destroys it.
 func (bi *BodyInterface) RemoveAndDestroyBody(bodyID BodyID) {
+	bi.releaseUserData(bodyID)
 	jphBodyInterfaceRemoveAndDestroyBody(bi.handle, uint32(bodyID))
 }
 
 // RemoveBody removes a body from the simulation without destroying it.
+// The body keeps its state and can be re-added with AddBody.
 func (bi *BodyInterface) RemoveBody(bodyID BodyID) {
 	jphBodyInterfaceRemoveBody(bi.handle, uint32(bodyID))
 }
 
 // DestroyBody destroys a body that has already been removed from the simulation.
 func (bi *BodyInterface) DestroyBody(bodyID BodyID) {
+	bi.releaseUserData(bodyID)
 	jphBodyInterfaceDestroyBody(bi.handle, uint32(bodyID))
 }
 
@@ -48,6 +89,37 @@ func (bi *BodyInterface) GetLinearVelocity(bodyID BodyID) Vec3 {
 	return v
 }
 
+// AddLinearVelocity adds to the linear velocity of a body and activates it.
+func (bi *BodyInterface) AddLinearVelocity(bodyID BodyID, velocity Vec3) {
+	jphBodyInterfaceAddLinearVelocity(bi.handle, uint32(bodyID), &velocity)
+}
+
+// SetAngularVelocity sets the angular velocity (rad/s) of a body.
+func (bi *BodyInterface) SetAngularVelocity(bodyID BodyID, angularVelocity Vec3) {
+	jphBodyInterfaceSetAngularVelocity(bi.handle, uint32(bodyID), &angularVelocity)
+}
+
+// GetAngularVelocity returns the angular velocity (rad/s) of a body.
+func (bi *BodyInterface) GetAngularVelocity(bodyID BodyID) Vec3 {
+	var v Vec3
+	jphBodyInterfaceGetAngularVelocity(bi.handle, uint32(bodyID), &v)
+	return v
+}
+
+// SetLinearAndAngularVelocity sets both the linear and angular velocity of a
+// body in a single call.
+func (bi *BodyInterface) SetLinearAndAngularVelocity(bodyID BodyID, linearVelocity, angularVelocity Vec3) {
+	jphBodyInterfaceSetLinearAndAngularVelocity(bi.handle, uint32(bodyID), &linearVelocity, &angularVelocity)
+}
+
+// GetPointVelocity returns the velocity of a world-space point attached to
+// the body, combining its linear and angular velocity.
+func (bi *BodyInterface) GetPointVelocity(bodyID BodyID, point Vec3) Vec3 {
+	var v Vec3
+	jphBodyInterfaceGetPointVelocity(bi.handle, uint32(bodyID), &point, &v)
+	return v
+}
+
 // GetCenterOfMassPosition returns the center-of-mass world position of a body.
 func (bi *BodyInterface) GetCenterOfMassPosition(bodyID BodyID) Vec3 {
 	var pos Vec3
@@ -79,6 +151,92 @@ func (bi *BodyInterface) GetRotation(bodyID BodyID) Quat {
 	return q
 }
 
+// SetPositionAndRotation sets the world position and rotation of a body in
+// a single call.
+func (bi *BodyInterface) SetPositionAndRotation(bodyID BodyID, position Vec3, rotation Quat, activation Activation) {
+	jphBodyInterfaceSetPositionAndRotation(bi.handle, uint32(bodyID), &position, &rotation, int32(activation))
+}
+
+// SetPositionRotationAndVelocity sets the position, rotation, and linear and
+// angular velocity of a body in a single call. If the body has been added to
+// the physics world, it is also activated.
+func (bi *BodyInterface) SetPositionRotationAndVelocity(bodyID BodyID, position Vec3, rotation Quat, linearVelocity, angularVelocity Vec3) {
+	jphBodyInterfaceSetPositionRotationAndVelocity(bi.handle, uint32(bodyID), &position, &rotation, &linearVelocity, &angularVelocity)
+}
+
+// GetObjectLayer returns the collision layer of a body.
+func (bi *BodyInterface) GetObjectLayer(bodyID BodyID) ObjectLayer {
+	return ObjectLayer(jphBodyInterfaceGetObjectLayer(bi.handle, uint32(bodyID)))
+}
+
+// SetObjectLayer moves a body to another collision layer and updates the
+// broad phase accordingly. Contacts with bodies the new layer does not
+// collide with are removed during the next step.
+func (bi *BodyInterface) SetObjectLayer(bodyID BodyID, layer ObjectLayer) {
+	jphBodyInterfaceSetObjectLayer(bi.handle, uint32(bodyID), uint32(layer))
+}
+
+// GetCollisionGroup returns the collision group of a body.
+func (bi *BodyInterface) GetCollisionGroup(bodyID BodyID) CollisionGroup {
+	var lock bodyLockRead
+	jphBodyLockInterfaceLockRead(bi.lockInterface, uint32(bodyID), &lock)
+	defer jphBodyLockInterfaceUnlockRead(bi.lockInterface, &lock)
+	if lock.Body == 0 {
+		return CollisionGroup{GroupID: CollisionGroupIDInvalid, SubGroupID: CollisionSubGroupIDInvalid}
+	}
+	var c collisionGroup
+	jphBodyGetCollisionGroup(lock.Body, &c)
+	return c.fromC()
+}
+
+// SetCollisionGroup sets the collision group of a body. The body keeps its
+// own reference to the group's GroupFilter.
+func (bi *BodyInterface) SetCollisionGroup(bodyID BodyID, group CollisionGroup) {
+	var lock bodyLockWrite
+	jphBodyLockInterfaceLockWrite(bi.lockInterface, uint32(bodyID), &lock)
+	defer jphBodyLockInterfaceUnlockWrite(bi.lockInterface, &lock)
+	if lock.Body == 0 {
+		return
+	}
+	c := group.toC()
+	jphBodySetCollisionGroup(lock.Body, &c)
+}
+
+// GetShape returns the shape currently used by a body, or nil if bodyID
+// does not address a body.
+//
+// The returned Shape holds its own reference to the C shape, so it stays
+// valid after SetShape replaces it or the body is destroyed. The caller must
+// call Destroy on it to release that reference.
+func (bi *BodyInterface) GetShape(bodyID BodyID) *Shape {
+	h := jphBodyInterfaceGetShape(bi.handle, uint32(bodyID))
+	if h == 0 {
+		return nil
+	}
+	jphShapeAddRef(h)
+	return &Shape{handle: h}
+}
+
+// SetShape replaces the shape of a body. The body takes its own reference to
+// the new shape and releases its reference to the old one, which is freed
+// unless a *Shape for it (the one it was created from, or one returned by
+// GetShape) has not been destroyed yet. If updateMassProperties is true, mass
+// and inertia are recalculated from the new shape.
+//
+// shape must not be nil; SetShape panics if it is. Use
+// CheckedBodyInterface.SetShape to get an error instead.
+func (bi *BodyInterface) SetShape(bodyID BodyID, shape *Shape, updateMassProperties bool, activation Activation) {
+	jphBodyInterfaceSetShape(bi.handle, uint32(bodyID), shape.handle, updateMassProperties, int32(activation))
+}
+
+// MoveKinematic sets the linear and angular velocity of a kinematic body so
+// that it reaches targetPosition and targetRotation after deltaTime seconds.
+// Call it before each PhysicsSystem.Update with the same deltaTime. Unlike
+// SetPosition, bodies resting on the kinematic body are carried along.
+func (bi *BodyInterface) MoveKinematic(bodyID BodyID, targetPosition Vec3, targetRotation Quat, deltaTime float32) {
+	jphBodyInterfaceMoveKinematic(bi.handle, uint32(bodyID), &targetPosition, &targetRotation, deltaTime)
+}
+
 // ActivateBody wakes a sleeping body.
 func (bi *BodyInterface) ActivateBody(bodyID BodyID) {
 	jphBodyInterfaceActivateBody(bi.handle, uint32(bodyID))
@@ -100,6 +258,19 @@ func (bi *BodyInterface) AddForce(bodyID BodyID, force Vec3) {
 	jphBodyInterfaceAddForce(bi.handle, uint32(bodyID), &force)
 }
 
+// AddForceAtPoint adds a force (in Newtons) at a world-space point on the
+// body, producing both linear acceleration and torque.
+// The force is applied for the duration of the next simulation step.
+func (bi *BodyInterface) AddForceAtPoint(bodyID BodyID, force Vec3, point Vec3) {
+	jphBodyInterfaceAddForce2(bi.handle, uint32(bodyID), &force, &point)
+}
+
+// AddTorque adds a torque (in Newton meters) to the body.
+// The torque is applied for the duration of the next simulation step.
+func (bi *BodyInterface) AddTorque(bodyID BodyID, torque Vec3) {
+	jphBodyInterfaceAddTorque(bi.handle, uint32(bodyID), &torque)
+}
+
 // AddImpulse applies an instantaneous impulse to the body's center of mass.
 func (bi *BodyInterface) AddImpulse(bodyID BodyID, impulse Vec3) {
 	jphBodyInterfaceAddImpulse(bi.handle, uint32(bodyID), &impulse)
@@ -155,3 +326,26 @@ func (bi *BodyInterface) GetMotionType(bodyID BodyID) MotionType {
 func (bi *BodyInterface) SetMotionType(bodyID BodyID, motionType MotionType, activation Activation) {
 	jphBodyInterfaceSetMotionType(bi.handle, uint32(bodyID), int32(motionType), int32(activation))
 }
+
+// GetInverseMass returns the inverse mass (1/kg) of a body.
+// Static bodies, which have no motion properties, report 0.
+func (bi *BodyInterface) GetInverseMass(bodyID BodyID) float32 {
+	var lock bodyLockRead
+	jphBodyLockInterfaceLockRead(bi.lockInterface, uint32(bodyID), &lock)
+	defer jphBodyLockInterfaceUnlockRead(bi.lockInterface, &lock)
+	if lock.Body == 0 {
+		return 0
+	}
+	mp := jphBodyGetMotionProperties(lock.Body)
+	if mp == 0 {
+		return 0
+	}
+	return jphMotionPropertiesGetInverseMassUnchecked(mp)
+}
+
+// GetInverseInertia returns the world-space inverse inertia tensor of a body.
+func (bi *BodyInterface) GetInverseInertia(bodyID BodyID) Matrix4x4 {
+	var m Matrix4x4
+	jphBodyInterfaceGetInverseInertia(bi.handle, uint32(bodyID), &m)
+	return m
+}
@@ -0,0 +1,59 @@
+package jolt
+
+import "iter"
+
+// AppendBodies appends the IDs of all bodies in the system, added or not, to
+// dst and returns the extended slice. Pass dst[:0] to reuse its storage.
+func (ps *PhysicsSystem) AppendBodies(dst []BodyID) []BodyID {
+	n := int(jphPhysicsSystemGetNumBodies(ps.handle))
+	if n == 0 {
+		return dst
+	}
+	start := len(dst)
+	dst = append(dst, make([]BodyID, n)...)
+	jphPhysicsSystemGetBodies(ps.handle, &dst[start], uint32(n))
+	return dst
+}
+
+// AppendActiveBodies appends the IDs of all awake bodies of the given type to
+// dst and returns the extended slice.
+//
+// joltc does not expose Jolt's active body list, so this scans all bodies
+// and locks each one to check its state, stopping once GetNumActiveBodies
+// bodies have been found. It is meant for tools such as editors and
+// serializers rather than per-frame use, and is therefore not offered as an
+// iterator like Bodies.
+func (ps *PhysicsSystem) AppendActiveBodies(dst []BodyID, bodyType BodyType) []BodyID {
+	want := int(ps.GetNumActiveBodies(bodyType))
+	if want == 0 {
+		return dst
+	}
+	start := len(dst)
+	dst = ps.AppendBodies(dst)
+	active := dst[:start]
+	for _, id := range dst[start:] {
+		if len(active)-start == want {
+			break
+		}
+		var lock bodyLockRead
+		jphBodyLockInterfaceLockRead(ps.lockInterface, uint32(id), &lock)
+		if lock.Body != 0 && jphBodyIsActive(lock.Body) && BodyType(jphBodyGetBodyType(lock.Body)) == bodyType {
+			active = append(active, id)
+		}
+		jphBodyLockInterfaceUnlockRead(ps.lockInterface, &lock)
+	}
+	return active
+}
+
+// Bodies returns an iterator over the IDs of all bodies in the system.
+// The IDs are captured when iteration starts, so bodies may be created or
+// destroyed inside the loop.
+func (ps *PhysicsSystem) Bodies() iter.Seq[BodyID] {
+	return func(yield func(BodyID) bool) {
+		for _, id := range ps.AppendBodies(nil) {
+			if !yield(id) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package jolt
+
+// ReadTransforms fills pos and rot with the world position and rotation of
+// each body in ids, so that pos[i] and rot[i] belong to ids[i].
+//
+// This is not a single batched native call: it makes one native call per
+// body, which locks that body on its own, instead of the two calls needed by
+// separate GetPosition and GetRotation calls. The bodies are therefore not
+// read as one consistent snapshot if another goroutine moves them meanwhile.
+//
+// pos and rot are caller-owned so they can be reused across frames without
+// allocating. ReadTransforms panics if either is shorter than ids.
+func (bi *BodyInterface) ReadTransforms(ids []BodyID, pos []Vec3, rot []Quat) {
+	if len(pos) < len(ids) || len(rot) < len(ids) {
+		panic("jolt: ReadTransforms: pos and rot must be at least len(ids)")
+	}
+	for i, id := range ids {
+		jphBodyInterfaceGetPositionAndRotation(bi.handle, uint32(id), &pos[i], &rot[i])
+	}
+}
+
+// ReadVelocities fills linear and angular with the linear (m/s) and angular
+// (rad/s) velocity of each body in ids. Like ReadTransforms, it makes one
+// native call per body, each locking that body on its own.
+//
+// ReadVelocities panics if linear or angular is shorter than ids.
+func (bi *BodyInterface) ReadVelocities(ids []BodyID, linear, angular []Vec3) {
+	if len(linear) < len(ids) || len(angular) < len(ids) {
+		panic("jolt: ReadVelocities: linear and angular must be at least len(ids)")
+	}
+	for i, id := range ids {
+		jphBodyInterfaceGetLinearAndAngularVelocity(bi.handle, uint32(id), &linear[i], &angular[i])
+	}
+}
@@ -0,0 +1,113 @@
+package jolt
+
+// BodySpec is a plain-Go description of a body, used with BodyInterface.Create
+// to create and add a body in one call without managing BodyCreationSettings.
+//
+// Every field's zero value is a usable default, so only the fields that
+// differ from the defaults need to be set:
+//
+//	id, err := bi.Create(jolt.BodySpec{
+//	    Shape:       sphere,
+//	    Position:    jolt.Vec3{Y: 10},
+//	    MotionType:  jolt.MotionTypeDynamic,
+//	    ObjectLayer: LayerMoving,
+//	})
+type BodySpec struct {
+	// Shape is the collision shape. It is required.
+	Shape *Shape
+
+	// Position is the initial world position of the body.
+	Position Vec3
+	// Rotation is the initial rotation. The zero value is treated as
+	// QuatIdentity.
+	Rotation Quat
+
+	// MotionType defaults to MotionTypeStatic.
+	MotionType MotionType
+	// ObjectLayer is the collision layer of the body.
+	ObjectLayer ObjectLayer
+	// Activation controls whether the body starts awake. The zero value is
+	// Activate; it has no effect on static bodies.
+	Activation Activation
+	// MotionQuality defaults to MotionQualityDiscrete.
+	MotionQuality MotionQuality
+
+	// Friction is the friction coefficient. Zero uses the Jolt default (0.2);
+	// call BodyInterface.SetFriction after creation for a frictionless body.
+	Friction float32
+	// Restitution is the bounciness, defaulting to 0 (no bounce).
+	Restitution float32
+	// GravityFactor scales gravity for this body. Zero uses the Jolt default
+	// (1.0); call BodyInterface.SetGravityFactor after creation to disable
+	// gravity.
+	GravityFactor float32
+	// DisableSleeping prevents the body from ever going to sleep.
+	DisableSleeping bool
+
+	// CollisionGroup assigns the body to a group for filtering with a
+	// GroupFilter. It is ignored unless CollisionGroup.GroupFilter is set.
+	CollisionGroup CollisionGroup
+
+	// Mass, if greater than zero, overrides the mass computed from the
+	// shape's density. Inertia is scaled to match
+	// (OverrideMassPropertiesCalculateInertia).
+	Mass float32
+
+	// LinearVelocity is the initial linear velocity (m/s).
+	LinearVelocity Vec3
+	// AngularVelocity is the initial angular velocity (rad/s).
+	AngularVelocity Vec3
+}
+
+// newSettings creates native BodyCreationSettings describing the spec.
+// The caller must Close the returned settings.
+func (spec *BodySpec) newSettings() *BodyCreationSettings {
+	rotation := spec.Rotation
+	if rotation == (Quat{}) {
+		rotation = QuatIdentity()
+	}
+	bcs := NewBodyCreationSettings(spec.Shape, spec.Position, rotation, spec.MotionType, spec.ObjectLayer)
+	if spec.MotionQuality != MotionQualityDiscrete {
+		bcs.SetMotionQuality(spec.MotionQuality)
+	}
+	if spec.Friction != 0 {
+		bcs.SetFriction(spec.Friction)
+	}
+	if spec.Restitution != 0 {
+		bcs.SetRestitution(spec.Restitution)
+	}
+	if spec.GravityFactor != 0 {
+		bcs.SetGravityFactor(spec.GravityFactor)
+	}
+	if spec.DisableSleeping {
+		bcs.SetAllowSleeping(false)
+	}
+	if spec.CollisionGroup.GroupFilter != nil {
+		bcs.SetCollisionGroup(spec.CollisionGroup)
+	}
+	if spec.Mass > 0 {
+		bcs.SetOverrideMassProperties(OverrideMassPropertiesCalculateInertia)
+		bcs.SetMassPropertiesOverride(MassProperties{Mass: spec.Mass})
+	}
+	if spec.LinearVelocity != (Vec3{}) {
+		bcs.SetLinearVelocity(spec.LinearVelocity)
+	}
+	if spec.AngularVelocity != (Vec3{}) {
+		bcs.SetAngularVelocity(spec.AngularVelocity)
+	}
+	return bcs
+}
+
+// Create creates a body from spec and adds it to the physics world.
+// The native creation settings are created and released internally.
+// It returns ErrShapeRequired if spec.Shape is nil and ErrBodyLimitReached if
+// the PhysicsSystem is full.
+func (bi *BodyInterface) Create(spec BodySpec) (BodyID, error) {
+	if spec.Shape == nil || spec.Shape.handle == 0 {
+		return BodyIDInvalid, ErrShapeRequired
+	}
+	bcs := spec.newSettings()
+	defer bcs.Close()
+
+	return bi.TryCreateAndAddBody(bcs, spec.Activation)
+}
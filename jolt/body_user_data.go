@@ -0,0 +1,58 @@
+package jolt
+
+// SetUserData attaches an arbitrary Go value to a body, replacing any value
+// set before. Passing nil detaches the current value. It does nothing if
+// bodyID does not address a body.
+//
+// The value is kept in a handle table and only an integer handle is stored in
+// the body, so it is safe from the garbage collector. It is released when the
+// body is destroyed through DestroyBody or RemoveAndDestroyBody, or when the
+// PhysicsSystem is closed.
+func (bi *BodyInterface) SetUserData(bodyID BodyID, value any) {
+	if exists, _ := bi.bodyState(bodyID); !exists {
+		return
+	}
+	bi.releaseUserData(bodyID)
+	if value == nil {
+		jphBodyInterfaceSetUserData(bi.handle, uint32(bodyID), 0)
+		return
+	}
+	h := handles.add(value)
+	jphBodyInterfaceSetUserData(bi.handle, uint32(bodyID), h)
+	// The body may have been destroyed since bodyState; then nothing holds h.
+	if jphBodyInterfaceGetUserData(bi.handle, uint32(bodyID)) != h {
+		handles.remove(h)
+	}
+}
+
+// GetUserData returns the Go value attached to a body with SetUserData, or
+// nil if there is none.
+func (bi *BodyInterface) GetUserData(bodyID BodyID) any {
+	return userDataValue(jphBodyInterfaceGetUserData(bi.handle, uint32(bodyID)))
+}
+
+// UserData returns the Go value attached to a body as a T. The boolean is
+// false if the body has no user data or it is not a T.
+//
+//	if e, ok := jolt.UserData[*Entity](bi, id); ok { ... }
+func UserData[T any](bi *BodyInterface, bodyID BodyID) (T, bool) {
+	v, ok := bi.GetUserData(bodyID).(T)
+	return v, ok
+}
+
+// releaseUserData removes the handle stored in a body from the handle table.
+// It does not clear the body's user data slot.
+func (bi *BodyInterface) releaseUserData(bodyID BodyID) {
+	if h := jphBodyInterfaceGetUserData(bi.handle, uint32(bodyID)); h != 0 {
+		handles.remove(h)
+	}
+}
+
+// userDataValue resolves a body user data handle to its Go value.
+func userDataValue(h uint64) any {
+	if h == 0 {
+		return nil
+	}
+	v, _ := handles.get(h)
+	return v
+}
@@ -0,0 +1,108 @@
+package jolt
+
+// CollisionGroupID identifies a group of bodies, such as all parts of one
+// ragdoll.
+type CollisionGroupID uint32
+
+// CollisionSubGroupID identifies a body within a collision group, such as a
+// single limb of a ragdoll.
+type CollisionSubGroupID uint32
+
+const (
+	// CollisionGroupIDInvalid is the group ID of a body that is not in a group.
+	CollisionGroupIDInvalid CollisionGroupID = 0xFFFFFFFF
+	// CollisionSubGroupIDInvalid is the sub-group ID of a body that is not in
+	// a sub-group.
+	CollisionSubGroupIDInvalid CollisionSubGroupID = 0xFFFFFFFF
+)
+
+// GroupFilter decides whether two bodies can collide based on their
+// CollisionGroup. It complements object layers, which cannot express rules
+// such as "parts of the same ragdoll do not collide with each other".
+//
+// Group filters are reference-counted in joltc; every body using one holds
+// its own reference. Call Destroy once the filter is no longer needed for
+// new bodies.
+type GroupFilter struct {
+	handle uintptr
+
+	// borrowed is set for filters read back from a body. The Go side holds
+	// no reference to them, so Destroy must not release one.
+	borrowed bool
+}
+
+// NewGroupFilterTable creates a group filter backed by a table of
+// numSubGroups x numSubGroups entries. Bodies in different groups always
+// collide; bodies in the same group collide only if collision is enabled
+// between their sub-groups. All sub-group pairs start enabled.
+func NewGroupFilterTable(numSubGroups uint32) *GroupFilter {
+	h := jphGroupFilterTableCreate(numSubGroups)
+	return &GroupFilter{handle: h}
+}
+
+// Destroy releases the Go side's reference to the filter. Bodies using it
+// keep it alive. It is a no-op for filters returned from a body.
+func (f *GroupFilter) Destroy() {
+	if f.handle != 0 && !f.borrowed {
+		jphGroupFilterDestroy(f.handle)
+		f.handle = 0
+	}
+}
+
+// EnableCollision enables collision between two sub-groups of the same group.
+// f must have been created with NewGroupFilterTable.
+func (f *GroupFilter) EnableCollision(subGroup1, subGroup2 CollisionSubGroupID) {
+	jphGroupFilterTableEnableCollision(f.handle, uint32(subGroup1), uint32(subGroup2))
+}
+
+// DisableCollision disables collision between two sub-groups of the same
+// group. f must have been created with NewGroupFilterTable.
+func (f *GroupFilter) DisableCollision(subGroup1, subGroup2 CollisionSubGroupID) {
+	jphGroupFilterTableDisableCollision(f.handle, uint32(subGroup1), uint32(subGroup2))
+}
+
+// IsCollisionEnabled reports whether two sub-groups of the same group collide.
+// f must have been created with NewGroupFilterTable.
+func (f *GroupFilter) IsCollisionEnabled(subGroup1, subGroup2 CollisionSubGroupID) bool {
+	return jphGroupFilterTableIsCollisionEnabled(f.handle, uint32(subGroup1), uint32(subGroup2))
+}
+
+// CanCollide reports whether bodies in the two collision groups may collide
+// according to this filter.
+func (f *GroupFilter) CanCollide(group1, group2 CollisionGroup) bool {
+	c1, c2 := group1.toC(), group2.toC()
+	return jphGroupFilterCanCollide(f.handle, &c1, &c2)
+}
+
+// CollisionGroup assigns a body to a group and sub-group for filtering by a
+// GroupFilter. Two bodies are only tested against each other's filter if it
+// is set; a CollisionGroup without a GroupFilter never prevents a collision.
+type CollisionGroup struct {
+	GroupFilter *GroupFilter
+	GroupID     CollisionGroupID
+	SubGroupID  CollisionSubGroupID
+}
+
+// toC converts the group to its joltc representation.
+func (g *CollisionGroup) toC() collisionGroup {
+	c := collisionGroup{
+		GroupID:    uint32(g.GroupID),
+		SubGroupID: uint32(g.SubGroupID),
+	}
+	if g.GroupFilter != nil {
+		c.GroupFilter = g.GroupFilter.handle
+	}
+	return c
+}
+
+// fromC converts a joltc collision group to its Go representation.
+func (c *collisionGroup) fromC() CollisionGroup {
+	g := CollisionGroup{
+		GroupID:    CollisionGroupID(c.GroupID),
+		SubGroupID: CollisionSubGroupID(c.SubGroupID),
+	}
+	if c.GroupFilter != 0 {
+		g.GroupFilter = &GroupFilter{handle: c.GroupFilter, borrowed: true}
+	}
+	return g
+}
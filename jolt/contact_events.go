@@ -0,0 +1,154 @@
+package jolt
+
+// ContactEventType tells whether a ContactEvent starts, continues or ends a
+// contact.
+type ContactEventType uint8
+
+const (
+	// ContactBegin is recorded when two bodies start touching.
+	ContactBegin ContactEventType = iota
+	// ContactPersist is recorded every step while two bodies keep touching,
+	// if ContactEvents.RecordPersisted is set.
+	ContactPersist
+	// ContactEnd is recorded when two bodies stop touching.
+	ContactEnd
+)
+
+// ContactEvent is a contact recorded by ContactEvents.
+type ContactEvent struct {
+	Type ContactEventType
+
+	Body1       BodyID
+	Body2       BodyID
+	SubShapeID1 SubShapeID
+	SubShapeID2 SubShapeID
+
+	// Point is the average world-space contact point on body 1. It is zero
+	// for ContactEnd events.
+	Point Vec3
+	// Normal is the world-space contact normal, pointing from body 1 towards
+	// body 2. It is zero for ContactEnd events.
+	Normal Vec3
+	// PenetrationDepth is how far the bodies overlap along Normal.
+	PenetrationDepth float32
+	// ApproachVelocity is the speed (m/s) at which the bodies move towards
+	// each other along Normal at Point. It is negative if they separate.
+	ApproachVelocity float32
+}
+
+// ContactEvents is a ContactListener that records contacts into a
+// fixed-size buffer, so that they can be handled on the caller's goroutine
+// after PhysicsSystem.Update returns:
+//
+//	events := jolt.NewContactEvents(1024)
+//	ps.SetContactListener(events)
+//	...
+//	ps.Update(dt, 1, jobSystem)
+//	buf = events.Drain(buf[:0])
+//
+// Recording is lock-free. When the buffer is full, further events of the
+// step are dropped and counted by Dropped.
+type ContactEvents struct {
+	NopContactListener
+
+	// RecordPersisted enables ContactPersist events. It must not be changed
+	// during Update.
+	RecordPersisted bool
+
+	buf eventBuffer[ContactEvent]
+}
+
+// NewContactEvents creates a collector that buffers up to capacity events
+// between drains.
+func NewContactEvents(capacity int) *ContactEvents {
+	return &ContactEvents{buf: newEventBuffer[ContactEvent](capacity)}
+}
+
+// Len returns the number of buffered events.
+func (c *ContactEvents) Len() int {
+	return c.buf.len()
+}
+
+// Dropped returns the total number of events lost because the buffer was
+// full.
+func (c *ContactEvents) Dropped() uint64 {
+	return c.buf.dropped.Load()
+}
+
+// Drain appends the buffered events to dst in the order they were recorded,
+// empties the buffer and returns the extended slice. It must not be called
+// during Update.
+func (c *ContactEvents) Drain(dst []ContactEvent) []ContactEvent {
+	return c.buf.drain(dst)
+}
+
+// DrainTo sends the buffered events to ch, blocking while ch is full, and
+// empties the buffer. It must not be called during Update.
+func (c *ContactEvents) DrainTo(ch chan<- ContactEvent) {
+	c.buf.drainTo(ch)
+}
+
+// OnContactAdded records a ContactBegin event.
+func (c *ContactEvents) OnContactAdded(body1, body2 *Body, manifold *ContactManifold, settings *ContactSettings) {
+	c.buf.record(newContactEvent(ContactBegin, body1, body2, manifold))
+}
+
+// OnContactPersisted records a ContactPersist event if RecordPersisted is set.
+func (c *ContactEvents) OnContactPersisted(body1, body2 *Body, manifold *ContactManifold, settings *ContactSettings) {
+	if c.RecordPersisted {
+		c.buf.record(newContactEvent(ContactPersist, body1, body2, manifold))
+	}
+}
+
+// OnContactRemoved records a ContactEnd event.
+func (c *ContactEvents) OnContactRemoved(pair SubShapeIDPair) {
+	c.buf.record(ContactEvent{
+		Type:        ContactEnd,
+		Body1:       pair.Body1ID,
+		Body2:       pair.Body2ID,
+		SubShapeID1: pair.SubShapeID1,
+		SubShapeID2: pair.SubShapeID2,
+	})
+}
+
+// newContactEvent describes the contact between body1 and body2.
+func newContactEvent(typ ContactEventType, body1, body2 *Body, manifold *ContactManifold) ContactEvent {
+	e := ContactEvent{
+		Type:             typ,
+		Body1:            body1.GetID(),
+		Body2:            body2.GetID(),
+		SubShapeID1:      manifold.GetSubShapeID1(),
+		SubShapeID2:      manifold.GetSubShapeID2(),
+		Normal:           manifold.GetWorldSpaceNormal(),
+		PenetrationDepth: manifold.GetPenetrationDepth(),
+	}
+	if n := manifold.GetPointCount(); n > 0 {
+		for i := range n {
+			p := manifold.GetWorldSpaceContactPointOn1(i)
+			e.Point.X += p.X
+			e.Point.Y += p.Y
+			e.Point.Z += p.Z
+		}
+		e.Point.X /= float32(n)
+		e.Point.Y /= float32(n)
+		e.Point.Z /= float32(n)
+	}
+	v1, v2 := pointVelocity(body1, e.Point), pointVelocity(body2, e.Point)
+	e.ApproachVelocity = (v1.X-v2.X)*e.Normal.X + (v1.Y-v2.Y)*e.Normal.Y + (v1.Z-v2.Z)*e.Normal.Z
+	return e
+}
+
+// pointVelocity returns the world-space velocity of the body at point.
+func pointVelocity(b *Body, point Vec3) Vec3 {
+	if b.IsStatic() {
+		return Vec3{}
+	}
+	v, w := b.GetLinearVelocity(), b.GetAngularVelocity()
+	com := b.GetCenterOfMassPosition()
+	r := Vec3{X: point.X - com.X, Y: point.Y - com.Y, Z: point.Z - com.Z}
+	return Vec3{
+		X: v.X + w.Y*r.Z - w.Z*r.Y,
+		Y: v.Y + w.Z*r.X - w.X*r.Z,
+		Z: v.Z + w.X*r.Y - w.Y*r.X,
+	}
+}
@@ -0,0 +1,393 @@
+package jolt
+
+import (
+	"sync"
+
+	"github.com/ebitengine/purego"
+)
+
+// ContactListener receives notifications about contacts between bodies.
+// Install it with PhysicsSystem.SetContactListener.
+//
+// The methods are called from Jolt's worker threads during
+// PhysicsSystem.Update, possibly from several threads at once, so
+// implementations must be safe for concurrent use. While a callback runs,
+// Jolt holds locks on the bodies involved: do not lock bodies or call
+// BodyInterface methods from a callback. The Body, ContactManifold and
+// ContactSettings arguments are only valid until the callback returns.
+//
+// The methods must not panic: a panic cannot unwind through Jolt's native
+// stack frames and terminates the process.
+//
+// Embed NopContactListener to implement only some of the methods.
+type ContactListener interface {
+	// OnContactValidate is called after the bounding boxes of two bodies
+	// overlap and a contact point was found, before the contact is created.
+	// body1 and body2 are read-only. Positions in result are relative to
+	// baseOffset.
+	OnContactValidate(body1, body2 *Body, baseOffset Vec3, result CollideShapeResult) ValidateResult
+
+	// OnContactAdded is called when two bodies start touching. settings may
+	// be modified to change how the contact is resolved.
+	OnContactAdded(body1, body2 *Body, manifold *ContactManifold, settings *ContactSettings)
+
+	// OnContactPersisted is called every step while two bodies keep
+	// touching.
+	OnContactPersisted(body1, body2 *Body, manifold *ContactManifold, settings *ContactSettings)
+
+	// OnContactRemoved is called when two sub shapes stop touching. The
+	// bodies may already have been removed, so only their IDs are given.
+	OnContactRemoved(pair SubShapeIDPair)
+}
+
+// NopContactListener implements ContactListener by accepting all contacts and
+// ignoring all notifications.
+type NopContactListener struct{}
+
+// OnContactValidate returns ValidateResultAcceptAllContacts.
+func (NopContactListener) OnContactValidate(body1, body2 *Body, baseOffset Vec3, result CollideShapeResult) ValidateResult {
+	return ValidateResultAcceptAllContacts
+}
+
+// OnContactAdded does nothing.
+func (NopContactListener) OnContactAdded(body1, body2 *Body, manifold *ContactManifold, settings *ContactSettings) {
+}
+
+// OnContactPersisted does nothing.
+func (NopContactListener) OnContactPersisted(body1, body2 *Body, manifold *ContactManifold, settings *ContactSettings) {
+}
+
+// OnContactRemoved does nothing.
+func (NopContactListener) OnContactRemoved(pair SubShapeIDPair) {}
+
+// CollideShapeResult describes a contact point found between two shapes,
+// as passed to ContactListener.OnContactValidate.
+type CollideShapeResult struct {
+	// ContactPointOn1 is the deepest point on the first shape.
+	ContactPointOn1 Vec3
+	// ContactPointOn2 is the deepest point on the second shape.
+	ContactPointOn2 Vec3
+	// PenetrationAxis is the direction to move shape 2 out of collision
+	// along the shortest path. Its length is arbitrary.
+	PenetrationAxis Vec3
+	// PenetrationDepth is how far the shapes overlap.
+	PenetrationDepth float32
+	// SubShapeID1 is the sub shape of the first body that was hit.
+	SubShapeID1 SubShapeID
+	// SubShapeID2 is the sub shape of the second body that was hit.
+	SubShapeID2 SubShapeID
+	// BodyID2 is the ID of the second body.
+	BodyID2 BodyID
+}
+
+// SubShapeIDPair identifies the sub shapes of two bodies in contact.
+type SubShapeIDPair struct {
+	Body1ID     BodyID
+	SubShapeID1 SubShapeID
+	Body2ID     BodyID
+	SubShapeID2 SubShapeID
+}
+
+// ContactManifold describes the contact area between two bodies, as passed to
+// ContactListener.OnContactAdded and OnContactPersisted. It is only valid
+// until the callback returns.
+type ContactManifold struct {
+	handle uintptr
+}
+
+// ptr returns the C manifold pointer, panicking if the callback has returned.
+func (m *ContactManifold) ptr() uintptr {
+	if m.handle == 0 {
+		panic("jolt: ContactManifold used after its callback returned")
+	}
+	return m.handle
+}
+
+// GetWorldSpaceNormal returns the contact normal in world space, pointing
+// from body 1 towards body 2.
+func (m *ContactManifold) GetWorldSpaceNormal() Vec3 {
+	var v Vec3
+	jphContactManifoldGetWorldSpaceNormal(m.ptr(), &v)
+	return v
+}
+
+// GetPenetrationDepth returns how far the bodies overlap along the normal.
+func (m *ContactManifold) GetPenetrationDepth() float32 {
+	return jphContactManifoldGetPenetrationDepth(m.ptr())
+}
+
+// GetSubShapeID1 returns the sub shape of body 1 that is in contact.
+func (m *ContactManifold) GetSubShapeID1() SubShapeID {
+	return SubShapeID(jphContactManifoldGetSubShapeID1(m.ptr()))
+}
+
+// GetSubShapeID2 returns the sub shape of body 2 that is in contact.
+func (m *ContactManifold) GetSubShapeID2() SubShapeID {
+	return SubShapeID(jphContactManifoldGetSubShapeID2(m.ptr()))
+}
+
+// GetPointCount returns the number of contact points in the manifold.
+func (m *ContactManifold) GetPointCount() int {
+	return int(jphContactManifoldGetPointCount(m.ptr()))
+}
+
+// GetWorldSpaceContactPointOn1 returns contact point i on the surface of
+// body 1, in world space.
+func (m *ContactManifold) GetWorldSpaceContactPointOn1(i int) Vec3 {
+	var v Vec3
+	jphContactManifoldGetWorldSpaceContactPointOn1(m.ptr(), uint32(i), &v)
+	return v
+}
+
+// GetWorldSpaceContactPointOn2 returns contact point i on the surface of
+// body 2, in world space.
+func (m *ContactManifold) GetWorldSpaceContactPointOn2(i int) Vec3 {
+	var v Vec3
+	jphContactManifoldGetWorldSpaceContactPointOn2(m.ptr(), uint32(i), &v)
+	return v
+}
+
+// ContactSettings controls how a new or persisting contact is resolved. It is
+// passed to ContactListener.OnContactAdded and OnContactPersisted and is only
+// valid until the callback returns.
+type ContactSettings struct {
+	handle uintptr
+}
+
+// ptr returns the C settings pointer, panicking if the callback has returned.
+func (s *ContactSettings) ptr() uintptr {
+	if s.handle == 0 {
+		panic("jolt: ContactSettings used after its callback returned")
+	}
+	return s.handle
+}
+
+// GetFriction returns the combined friction of the two bodies for this
+// contact.
+func (s *ContactSettings) GetFriction() float32 {
+	return jphContactSettingsGetFriction(s.ptr())
+}
+
+// SetFriction overrides the combined friction for this contact.
+func (s *ContactSettings) SetFriction(friction float32) {
+	jphContactSettingsSetFriction(s.ptr(), friction)
+}
+
+// GetRestitution returns the combined restitution of the two bodies for this
+// contact.
+func (s *ContactSettings) GetRestitution() float32 {
+	return jphContactSettingsGetRestitution(s.ptr())
+}
+
+// SetRestitution overrides the combined restitution for this contact, for
+// example to make a bouncy pad.
+func (s *ContactSettings) SetRestitution(restitution float32) {
+	jphContactSettingsSetRestitution(s.ptr(), restitution)
+}
+
+// GetInvMassScale1 returns the scale applied to the inverse mass of body 1.
+func (s *ContactSettings) GetInvMassScale1() float32 {
+	return jphContactSettingsGetInvMassScale1(s.ptr())
+}
+
+// SetInvMassScale1 scales the inverse mass of body 1 for this contact only.
+// A value of 0 makes body 1 behave as if it had infinite mass, so that it
+// pushes body 2 without being pushed back.
+func (s *ContactSettings) SetInvMassScale1(scale float32) {
+	jphContactSettingsSetInvMassScale1(s.ptr(), scale)
+}
+
+// GetInvInertiaScale1 returns the scale applied to the inverse inertia of
+// body 1.
+func (s *ContactSettings) GetInvInertiaScale1() float32 {
+	return jphContactSettingsGetInvInertiaScale1(s.ptr())
+}
+
+// SetInvInertiaScale1 scales the inverse inertia of body 1 for this contact
+// only. It is usually set together with SetInvMassScale1.
+func (s *ContactSettings) SetInvInertiaScale1(scale float32) {
+	jphContactSettingsSetInvInertiaScale1(s.ptr(), scale)
+}
+
+// GetInvMassScale2 returns the scale applied to the inverse mass of body 2.
+func (s *ContactSettings) GetInvMassScale2() float32 {
+	return jphContactSettingsGetInvMassScale2(s.ptr())
+}
+
+// SetInvMassScale2 scales the inverse mass of body 2 for this contact only.
+func (s *ContactSettings) SetInvMassScale2(scale float32) {
+	jphContactSettingsSetInvMassScale2(s.ptr(), scale)
+}
+
+// GetInvInertiaScale2 returns the scale applied to the inverse inertia of
+// body 2.
+func (s *ContactSettings) GetInvInertiaScale2() float32 {
+	return jphContactSettingsGetInvInertiaScale2(s.ptr())
+}
+
+// SetInvInertiaScale2 scales the inverse inertia of body 2 for this contact
+// only.
+func (s *ContactSettings) SetInvInertiaScale2(scale float32) {
+	jphContactSettingsSetInvInertiaScale2(s.ptr(), scale)
+}
+
+// GetIsSensor returns whether the contact is treated as a sensor contact.
+func (s *ContactSettings) GetIsSensor() bool {
+	return jphContactSettingsGetIsSensor(s.ptr())
+}
+
+// SetIsSensor makes the contact a sensor contact: it is still reported, but
+// the bodies do not push each other apart.
+func (s *ContactSettings) SetIsSensor(sensor bool) {
+	jphContactSettingsSetIsSensor(s.ptr(), sensor)
+}
+
+// GetRelativeLinearSurfaceVelocity returns the linear velocity (m/s) of the
+// surface of body 2 relative to body 1, in world space.
+func (s *ContactSettings) GetRelativeLinearSurfaceVelocity() Vec3 {
+	var v Vec3
+	jphContactSettingsGetRelativeLinearSurfaceVelocity(s.ptr(), &v)
+	return v
+}
+
+// SetRelativeLinearSurfaceVelocity sets the linear velocity (m/s) of the
+// surface of body 2 relative to body 1, in world space. Friction drags
+// resting bodies along with it, as on a conveyor belt.
+func (s *ContactSettings) SetRelativeLinearSurfaceVelocity(velocity Vec3) {
+	jphContactSettingsSetRelativeLinearSurfaceVelocity(s.ptr(), &velocity)
+}
+
+// GetRelativeAngularSurfaceVelocity returns the angular velocity (rad/s) of
+// the surface of body 2 relative to body 1, in world space.
+func (s *ContactSettings) GetRelativeAngularSurfaceVelocity() Vec3 {
+	var v Vec3
+	jphContactSettingsGetRelativeAngularSurfaceVelocity(s.ptr(), &v)
+	return v
+}
+
+// SetRelativeAngularSurfaceVelocity sets the angular velocity (rad/s) of the
+// surface of body 2 relative to body 1, in world space, as on a turntable.
+func (s *ContactSettings) SetRelativeAngularSurfaceVelocity(velocity Vec3) {
+	jphContactSettingsSetRelativeAngularSurfaceVelocity(s.ptr(), &velocity)
+}
+
+// SetContactListener installs l to receive contact notifications, replacing
+// any previous listener. Pass nil to remove the listener. It must not be
+// called during Update.
+func (ps *PhysicsSystem) SetContactListener(l ContactListener) {
+	old := ps.contactListener
+	ps.contactListener = nativeListener{}
+	if l != nil {
+		registerContactListenerProcs()
+		ps.contactListener.slot = contactListeners.add(l)
+		ps.contactListener.handle = jphContactListenerCreate(uintptr(ps.contactListener.slot))
+	}
+	jphPhysicsSystemSetContactListener(ps.handle, ps.contactListener.handle)
+	old.release(jphContactListenerDestroy, contactListeners.remove)
+}
+
+// nativeListener is a C listener object together with the slot of the Go
+// value it dispatches to. Listener callbacks run on Jolt's worker threads for
+// every contact or step, so their Go values live in slot tables rather than
+// the locked handle table.
+type nativeListener struct {
+	handle uintptr
+	slot   uint32
+}
+
+// release destroys the C listener with destroy and frees its slot with
+// remove. Releasing an empty listener is a no-op.
+func (l *nativeListener) release(destroy func(listener uintptr), remove func(slot uint32)) {
+	if l.handle != 0 {
+		destroy(l.handle)
+		remove(l.slot)
+	}
+	*l = nativeListener{}
+}
+
+// callbackBody wraps a body passed to a listener callback. Jolt already holds
+// the body's lock, so the Body has no lock of its own; the caller invalidates
+// it when the callback returns.
+func callbackBody(h uintptr) *Body {
+	return &Body{handle: h, id: BodyID(jphBodyGetID(h))}
+}
+
+// contactListenerProcs mirrors the C struct JPH_ContactListener_Procs.
+type contactListenerProcs struct {
+	OnContactValidate  uintptr
+	OnContactAdded     uintptr
+	OnContactPersisted uintptr
+	OnContactRemoved   uintptr
+}
+
+var contactListenerProcsOnce sync.Once
+
+// registerContactListenerProcs installs the Go callbacks shared by all
+// contact listeners. joltc keeps a single procs table and passes each
+// listener's handle as userData; purego callbacks are never freed, so they
+// are created only once.
+func registerContactListenerProcs() {
+	contactListenerProcsOnce.Do(func() {
+		procs := contactListenerProcs{
+			OnContactValidate:  purego.NewCallback(onContactValidate),
+			OnContactAdded:     purego.NewCallback(onContactAdded),
+			OnContactPersisted: purego.NewCallback(onContactPersisted),
+			OnContactRemoved:   purego.NewCallback(onContactRemoved),
+		}
+		jphContactListenerSetProcs(&procs)
+	})
+}
+
+// contactListeners holds the Go listeners of installed contact listeners.
+var contactListeners slotTable[ContactListener]
+
+// contactListenerFor returns the listener registered under userData.
+func contactListenerFor(userData uintptr) ContactListener {
+	return contactListeners.get(userData)
+}
+
+func onContactValidate(userData, body1, body2 uintptr, baseOffset *Vec3, result *collideShapeResult) uintptr {
+	l := contactListenerFor(userData)
+	if l == nil {
+		return uintptr(ValidateResultAcceptAllContacts)
+	}
+	b1, b2 := callbackBody(body1), callbackBody(body2)
+	r := l.OnContactValidate(b1, b2, *baseOffset, CollideShapeResult{
+		ContactPointOn1:  result.ContactPointOn1,
+		ContactPointOn2:  result.ContactPointOn2,
+		PenetrationAxis:  result.PenetrationAxis,
+		PenetrationDepth: result.PenetrationDepth,
+		SubShapeID1:      SubShapeID(result.SubShapeID1),
+		SubShapeID2:      SubShapeID(result.SubShapeID2),
+		BodyID2:          BodyID(result.BodyID2),
+	})
+	b1.handle, b2.handle = 0, 0
+	return uintptr(r)
+}
+
+func onContactAdded(userData, body1, body2, manifold, settings uintptr) uintptr {
+	if l := contactListenerFor(userData); l != nil {
+		b1, b2 := callbackBody(body1), callbackBody(body2)
+		m, s := &ContactManifold{handle: manifold}, &ContactSettings{handle: settings}
+		l.OnContactAdded(b1, b2, m, s)
+		b1.handle, b2.handle, m.handle, s.handle = 0, 0, 0, 0
+	}
+	return 0
+}
+
+func onContactPersisted(userData, body1, body2, manifold, settings uintptr) uintptr {
+	if l := contactListenerFor(userData); l != nil {
+		b1, b2 := callbackBody(body1), callbackBody(body2)
+		m, s := &ContactManifold{handle: manifold}, &ContactSettings{handle: settings}
+		l.OnContactPersisted(b1, b2, m, s)
+		b1.handle, b2.handle, m.handle, s.handle = 0, 0, 0, 0
+	}
+	return 0
+}
+
+func onContactRemoved(userData uintptr, pair *SubShapeIDPair) uintptr {
+	if l := contactListenerFor(userData); l != nil {
+		l.OnContactRemoved(*pair)
+	}
+	return 0
+}
@@ -27,7 +27,12 @@
 //   - [JobSystem].Close
 //   - [PhysicsSystem].Close
 //   - [BodyCreationSettings].Close
-//   - [Shape].Destroy (only for shapes not referenced by any body)
+//   - [Shape].Destroy (for shapes not referenced by any body, and for shapes
+//     returned by [BodyInterface].GetShape or [Body].GetShape)
+//   - Destroy on layer interfaces and filters created from Go functions, such
+//     as [NewObjectLayerPairFilter], after closing the [PhysicsSystem]
+//   - [QueryFilter].Destroy
+//   - [Body].Unlock (for bodies locked with [PhysicsSystem].LockBodyRead/LockBodyWrite)
 //
 // # Thread Safety
 //
@@ -35,6 +40,16 @@
 // own job system. However, Go wrapper types are not safe for concurrent use
 // from multiple goroutines without external synchronization.
 //
+// Listeners installed on a PhysicsSystem, such as a [ContactListener] or a
+// [BodyActivationListener], are called from Jolt's worker threads during
+// [PhysicsSystem].Update and must be safe for concurrent use. [ContactEvents]
+// and [BodyActivationEvents] buffer notifications so that they can be handled
+// on a single goroutine after Update returns.
+//
+// Listeners, step listeners and filter functions are called from native code
+// and must not panic: a panic cannot unwind through Jolt's stack frames and
+// terminates the process.
+//
 // # Limitations
 //
 //   - Requires the joltc shared library (libjoltc.so / libjoltc.dylib / joltc.dll)
@@ -0,0 +1,16 @@
+package jolt
+
+import "errors"
+
+// ErrBodyLimitReached is returned when a body cannot be created because the
+// PhysicsSystem already holds PhysicsSystemConfig.MaxBodies bodies.
+var ErrBodyLimitReached = errors.New("jolt: body limit reached")
+
+// ErrInvalidBody is returned by CheckedBodyInterface when a BodyID is
+// BodyIDInvalid, refers to a destroyed body, or refers to a body that is not
+// in the expected added/removed state.
+var ErrInvalidBody = errors.New("jolt: invalid body")
+
+// ErrShapeRequired is returned when a body is created or changed without a
+// shape.
+var ErrShapeRequired = errors.New("jolt: shape is required")
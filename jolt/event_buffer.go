@@ -0,0 +1,50 @@
+package jolt
+
+import "sync/atomic"
+
+// eventBuffer is a fixed-size buffer that listener callbacks on Jolt's worker
+// threads append to without locking, and that is drained on the caller's
+// goroutine between updates.
+type eventBuffer[T any] struct {
+	events  []T
+	n       atomic.Int64
+	dropped atomic.Uint64
+}
+
+func newEventBuffer[T any](capacity int) eventBuffer[T] {
+	return eventBuffer[T]{events: make([]T, capacity)}
+}
+
+// record stores e in the next free slot, or counts it as dropped.
+func (b *eventBuffer[T]) record(e T) {
+	i := b.n.Add(1) - 1
+	if i >= int64(len(b.events)) {
+		b.dropped.Add(1)
+		return
+	}
+	b.events[i] = e
+}
+
+func (b *eventBuffer[T]) len() int {
+	return int(min(b.n.Load(), int64(len(b.events))))
+}
+
+func (b *eventBuffer[T]) drain(dst []T) []T {
+	dst = append(dst, b.events[:b.len()]...)
+	b.reset()
+	return dst
+}
+
+func (b *eventBuffer[T]) drainTo(ch chan<- T) {
+	for _, e := range b.events[:b.len()] {
+		ch <- e
+	}
+	b.reset()
+}
+
+// reset empties the buffer, clearing the slots so that recorded values can
+// be garbage collected.
+func (b *eventBuffer[T]) reset() {
+	clear(b.events[:b.len()])
+	b.n.Store(0)
+}
@@ -0,0 +1,52 @@
+package jolt
+
+import "sync"
+
+// handleTable maps integer handles to Go values. Handles can be stored in C
+// memory (such as a body's uint64 user data slot, or the userData pointer of
+// a joltc callback) without exposing Go pointers to C, and keep the values
+// reachable for the garbage collector until they are removed.
+//
+// It is safe for concurrent use, including from joltc worker threads.
+type handleTable struct {
+	mu     sync.RWMutex
+	next   uint64
+	values map[uint64]any
+}
+
+// handles is the package-wide handle table.
+var handles handleTable
+
+// add stores v and returns its handle. Handles are never 0.
+func (t *handleTable) add(v any) uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.values == nil {
+		t.values = make(map[uint64]any)
+	}
+	t.next++
+	t.values[t.next] = v
+	return t.next
+}
+
+// get returns the value stored for h.
+func (t *handleTable) get(h uint64) (any, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	v, ok := t.values[h]
+	return v, ok
+}
+
+// remove deletes h from the table. Removing an unknown handle is a no-op.
+func (t *handleTable) remove(h uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.values, h)
+}
+
+// len returns the number of values in the table.
+func (t *handleTable) len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.values)
+}
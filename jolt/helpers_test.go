@@ -0,0 +1,47 @@
+package jolt
+
+import (
+	"math"
+	"testing"
+)
+
+// newTestSystem creates a PhysicsSystem with two object layers, 0 and 1,
+// that collide with each other and themselves, closed when the test ends. The test is skipped if the
+// joltc library is not available.
+func newTestSystem(tb testing.TB, maxBodies uint32) *PhysicsSystem {
+	tb.Helper()
+	if err := Init(); err != nil {
+		tb.Skipf("joltc not available: %v", err)
+	}
+
+	bpLayer := NewBroadPhaseLayerInterfaceTable(2, 1)
+	bpLayer.MapObjectToBroadPhaseLayer(0, 0)
+	bpLayer.MapObjectToBroadPhaseLayer(1, 0)
+	objFilter := NewObjectLayerPairFilterTable(2)
+	objFilter.EnableCollision(0, 0)
+	objFilter.EnableCollision(0, 1)
+	objFilter.EnableCollision(1, 1)
+	bpFilter := NewObjectVsBroadPhaseLayerFilterTable(bpLayer, 1, objFilter, 2)
+
+	ps := NewPhysicsSystem(&PhysicsSystemConfig{
+		MaxBodies:             maxBodies,
+		BroadPhaseLayer:       bpLayer,
+		ObjectLayerPairFilter: objFilter,
+		ObjectVsBPLayerFilter: bpFilter,
+	})
+	tb.Cleanup(ps.Close)
+	return ps
+}
+
+// near reports whether a and b differ by at most 1e-5.
+func near(a, b float32) bool {
+	return math.Abs(float64(a-b)) <= 1e-5
+}
+
+func vec3Near(a, b Vec3) bool {
+	return near(a.X, b.X) && near(a.Y, b.Y) && near(a.Z, b.Z)
+}
+
+func quatNear(a, b Quat) bool {
+	return near(a.X, b.X) && near(a.Y, b.Y) && near(a.Z, b.Z) && near(a.W, b.W)
+}
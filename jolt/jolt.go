@@ -72,6 +72,7 @@ func (js *JobSystem) Close() {
 // the lifetime of the PhysicsSystem.
 type BroadPhaseLayerInterface struct {
 	handle uintptr
+	slot   uint32 // broadPhaseLayerMappers slot, for callback-based interfaces
 }
 
 // NewBroadPhaseLayerInterfaceTable creates a table-based mapping from
@@ -89,6 +90,7 @@ func (b *BroadPhaseLayerInterface) MapObjectToBroadPhaseLayer(objectLayer Object
 // ObjectLayerPairFilter determines which object layer pairs should collide.
 type ObjectLayerPairFilter struct {
 	handle uintptr
+	slot   uint32 // objectLayerPairFilters slot, for callback-based filters
 }
 
 // NewObjectLayerPairFilterTable creates a table-based object layer pair filter.
@@ -110,6 +112,7 @@ func (f *ObjectLayerPairFilter) DisableCollision(layer1, layer2 ObjectLayer) {
 // ObjectVsBroadPhaseLayerFilter determines which object layers collide with which broad-phase layers.
 type ObjectVsBroadPhaseLayerFilter struct {
 	handle uintptr
+	slot   uint32 // objectVsBroadPhaseLayerFilters slot, for callback-based filters
 }
 
 // NewObjectVsBroadPhaseLayerFilterTable creates a table-based filter using the
@@ -1,6 +1,13 @@
 package jolt
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
 
 func TestVec3(t *testing.T) {
 	v := Vec3{X: 1.0, Y: 2.0, Z: 3.0}
@@ -118,3 +125,1357 @@ func TestGetLibraryName(t *testing.T) {
 		t.Errorf("unexpected library name: %s", name)
 	}
 }
+
+func TestMatrix4x4Identity(t *testing.T) {
+	m := Matrix4x4Identity()
+	if m.M11 != 1 || m.M22 != 1 || m.M33 != 1 || m.M44 != 1 {
+		t.Errorf("Matrix4x4Identity diagonal incorrect: got %+v", m)
+	}
+	if m.M12 != 0 || m.M21 != 0 || m.M34 != 0 || m.M43 != 0 {
+		t.Errorf("Matrix4x4Identity off-diagonal should be 0: got %+v", m)
+	}
+}
+
+func TestOverrideMassPropertiesConstants(t *testing.T) {
+	if OverrideMassPropertiesCalculateMassAndInertia != 0 {
+		t.Errorf("OverrideMassPropertiesCalculateMassAndInertia should be 0, got %d", OverrideMassPropertiesCalculateMassAndInertia)
+	}
+	if OverrideMassPropertiesCalculateInertia != 1 {
+		t.Errorf("OverrideMassPropertiesCalculateInertia should be 1, got %d", OverrideMassPropertiesCalculateInertia)
+	}
+	if OverrideMassPropertiesMassAndInertiaProvided != 2 {
+		t.Errorf("OverrideMassPropertiesMassAndInertiaProvided should be 2, got %d", OverrideMassPropertiesMassAndInertiaProvided)
+	}
+}
+
+func TestBodySpecCreateRequiresShape(t *testing.T) {
+	bi := &BodyInterface{}
+	id, err := bi.Create(BodySpec{MotionType: MotionTypeDynamic})
+	if !errors.Is(err, ErrShapeRequired) {
+		t.Fatalf("Create without a shape = %v, want ErrShapeRequired", err)
+	}
+	if id != BodyIDInvalid {
+		t.Errorf("Create failure should return the invalid BodyID, got %#x", uint32(id))
+	}
+}
+
+func TestKeyframeTrackSample(t *testing.T) {
+	track := &KeyframeTrack{Keyframes: []Keyframe{
+		{Time: 0, Position: Vec3{X: 0}, Rotation: QuatIdentity()},
+		{Time: 2, Position: Vec3{X: 10}, Rotation: QuatIdentity()},
+	}}
+	if d := track.Duration(); d != 2 {
+		t.Errorf("Duration should be 2, got %v", d)
+	}
+	tests := []struct {
+		mode TrackMode
+		time float32
+		want float32
+	}{
+		{TrackOnce, -1, 0},
+		{TrackOnce, 1, 5},
+		{TrackOnce, 3, 10},
+		{TrackLoop, 3, 5},
+		{TrackLoop, -0.5, 7.5},
+		{TrackPingPong, 3, 5},
+		{TrackPingPong, 3.5, 2.5},
+	}
+	for _, tt := range tests {
+		track.Mode = tt.mode
+		pos, rot := track.Sample(tt.time)
+		if pos.X != tt.want {
+			t.Errorf("mode %d, time %v: X should be %v, got %v", tt.mode, tt.time, tt.want, pos.X)
+		}
+		if rot != QuatIdentity() {
+			t.Errorf("mode %d, time %v: rotation should stay identity, got %+v", tt.mode, tt.time, rot)
+		}
+	}
+}
+
+func TestKeyframeTrackSlerp(t *testing.T) {
+	// 180 degrees around Y, sampled halfway, is 90 degrees around Y.
+	track := &KeyframeTrack{Keyframes: []Keyframe{
+		{Time: 0, Rotation: QuatIdentity()},
+		{Time: 1, Rotation: Quat{Y: 1}},
+	}}
+	_, rot := track.Sample(0.5)
+	const s = 0.70710677
+	if math.Abs(float64(rot.Y-s)) > 1e-5 || math.Abs(float64(rot.W-s)) > 1e-5 || rot.X != 0 || rot.Z != 0 {
+		t.Errorf("halfway rotation should be (0, %v, 0, %v), got %+v", s, s, rot)
+	}
+}
+
+func TestKeyframeTrackEase(t *testing.T) {
+	track := &KeyframeTrack{
+		Keyframes: []Keyframe{
+			{Time: 0, Rotation: QuatIdentity()},
+			{Time: 1, Position: Vec3{Y: 1}, Rotation: QuatIdentity()},
+		},
+		Ease: EaseInOut,
+	}
+	if pos, _ := track.Sample(0.25); pos.Y >= 0.25 {
+		t.Errorf("EaseInOut should start slower than linear, got %v at 0.25", pos.Y)
+	}
+	if pos, _ := track.Sample(0.5); pos.Y != 0.5 {
+		t.Errorf("EaseInOut should pass through 0.5 at the midpoint, got %v", pos.Y)
+	}
+}
+
+func TestShapeDestroyTwiceIsNoop(t *testing.T) {
+	// A destroyed shape has no handle, so a second Destroy must not call
+	// into joltc.
+	s := &Shape{}
+	s.Destroy()
+	s.Destroy()
+}
+
+func TestCheckedSetShapeRequiresShape(t *testing.T) {
+	c := (&BodyInterface{}).Checked()
+	if err := c.SetShape(1, nil, false, DontActivate); !errors.Is(err, ErrShapeRequired) {
+		t.Errorf("SetShape(nil) = %v, want ErrShapeRequired", err)
+	}
+}
+
+func TestCheckedBodyInterfaceCoversBodyMethods(t *testing.T) {
+	checked := reflect.TypeOf(&CheckedBodyInterface{})
+	bodyID := reflect.TypeOf(BodyID(0))
+	unchecked := reflect.TypeOf(&BodyInterface{})
+	for i := range unchecked.NumMethod() {
+		m := unchecked.Method(i)
+		if m.Type.NumIn() < 2 || m.Type.In(1) != bodyID || m.Name == "ValidateBodyID" {
+			continue
+		}
+		if _, ok := checked.MethodByName(m.Name); !ok {
+			t.Errorf("CheckedBodyInterface is missing %s", m.Name)
+		}
+	}
+}
+
+func TestGetShapeOutlivesBody(t *testing.T) {
+	ps := newTestSystem(t, 16)
+	bi := ps.GetBodyInterface()
+	sphere := NewSphereShape(0.5)
+	id, err := bi.Create(BodySpec{Shape: sphere, MotionType: MotionTypeDynamic})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sphere.Destroy() // the body keeps its own reference
+
+	got := bi.GetShape(id)
+	want := got.GetMassProperties().Mass
+	if want <= 0 {
+		t.Fatalf("sphere mass = %v, want > 0", want)
+	}
+
+	box := NewBoxShape(Vec3{X: 1, Y: 1, Z: 1}, 0.05)
+	bi.SetShape(id, box, true, DontActivate)
+	box.Destroy()
+	if replaced := bi.GetShape(id); replaced.GetMassProperties().Mass == want {
+		t.Error("SetShape did not replace the shape")
+	} else {
+		replaced.Destroy()
+	}
+
+	// got still holds a reference to the sphere after it was replaced and
+	// after the body is gone.
+	bi.RemoveAndDestroyBody(id)
+	if m := got.GetMassProperties().Mass; m != want {
+		t.Errorf("mass through retained shape = %v, want %v", m, want)
+	}
+	got.Destroy()
+}
+
+func TestCollisionGroupConversion(t *testing.T) {
+	filter := &GroupFilter{handle: 0x1000}
+	g := CollisionGroup{GroupFilter: filter, GroupID: 7, SubGroupID: 3}
+	c := g.toC()
+	if c.GroupFilter != 0x1000 || c.GroupID != 7 || c.SubGroupID != 3 {
+		t.Errorf("toC incorrect: got %+v", c)
+	}
+	back := c.fromC()
+	if back.GroupFilter == nil || back.GroupFilter.handle != 0x1000 || !back.GroupFilter.borrowed {
+		t.Errorf("fromC should return a borrowed filter with the same handle, got %+v", back.GroupFilter)
+	}
+	if back.GroupID != 7 || back.SubGroupID != 3 {
+		t.Errorf("fromC IDs incorrect: got %+v", back)
+	}
+
+	none := (&CollisionGroup{}).toC()
+	if none.GroupFilter != 0 {
+		t.Errorf("CollisionGroup without filter should have a nil filter handle, got %#x", none.GroupFilter)
+	}
+}
+
+func TestCollisionGroupRoundTrip(t *testing.T) {
+	for _, c := range []collisionGroup{
+		{},
+		{GroupFilter: 0x2000, GroupID: 1, SubGroupID: 2},
+		{GroupFilter: 0, GroupID: 5, SubGroupID: 9},
+		{GroupFilter: 0x3000, GroupID: uint32(CollisionGroupIDInvalid), SubGroupID: uint32(CollisionSubGroupIDInvalid)},
+	} {
+		g := c.fromC()
+		if got := g.toC(); got != c {
+			t.Errorf("toC(fromC(%+v)) = %+v", c, got)
+		}
+		if (g.GroupFilter == nil) != (c.GroupFilter == 0) {
+			t.Errorf("fromC(%+v) filter = %+v", c, g.GroupFilter)
+		}
+	}
+
+	// Destroying a filter read back from a body must not release it, so it
+	// needs no native call.
+	g := (&collisionGroup{GroupFilter: 0x2000}).fromC()
+	g.GroupFilter.Destroy()
+	if g.GroupFilter.handle != 0x2000 {
+		t.Error("Destroy released a borrowed GroupFilter")
+	}
+}
+
+func TestHandleTable(t *testing.T) {
+	var ht handleTable
+	type entity struct{ name string }
+	e := &entity{name: "crate"}
+
+	h := ht.add(e)
+	if h == 0 {
+		t.Fatal("handles must never be 0")
+	}
+	if h2 := ht.add("other"); h2 == h {
+		t.Errorf("handles must be unique, got %d twice", h)
+	}
+	v, ok := ht.get(h)
+	if !ok || v.(*entity) != e {
+		t.Errorf("get(%d) = %v, %v; want %v, true", h, v, ok, e)
+	}
+	ht.remove(h)
+	if _, ok := ht.get(h); ok {
+		t.Errorf("get after remove should fail")
+	}
+	ht.remove(h) // removing twice is a no-op
+}
+
+func TestUserDataValue(t *testing.T) {
+	if v := userDataValue(0); v != nil {
+		t.Errorf("handle 0 should resolve to nil, got %v", v)
+	}
+	h := handles.add(42)
+	defer handles.remove(h)
+	if v := userDataValue(h); v != 42 {
+		t.Errorf("userDataValue(%d) = %v, want 42", h, v)
+	}
+}
+
+func TestUserDataRoundTrip(t *testing.T) {
+	type entity struct{ name string }
+
+	ps := newTestSystem(t, 16)
+	bi := ps.GetBodyInterface()
+	id, err := bi.Create(BodySpec{Shape: NewSphereShape(0.5)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := handles.len()
+
+	player := &entity{name: "player"}
+	bi.SetUserData(id, player)
+	if v := bi.GetUserData(id); v != player {
+		t.Errorf("GetUserData = %v, want %v", v, player)
+	}
+	if e, ok := UserData[*entity](bi, id); !ok || e != player {
+		t.Errorf("UserData[*entity] = %v, %v", e, ok)
+	}
+	if s, ok := UserData[string](bi, id); ok || s != "" {
+		t.Errorf("UserData[string] = %q, %v; want zero value and false", s, ok)
+	}
+
+	// Replacing a value releases the old handle.
+	bi.SetUserData(id, "crate")
+	if s, _ := UserData[string](bi, id); s != "crate" || handles.len() != before+1 {
+		t.Errorf("after replace: value %q, %d handles; want crate, %d", s, handles.len(), before+1)
+	}
+
+	bi.SetUserData(id, nil)
+	if v := bi.GetUserData(id); v != nil || handles.len() != before {
+		t.Errorf("after SetUserData(nil): value %v, %d handles; want nil, %d", v, handles.len(), before)
+	}
+
+	// Invalid and stale IDs are ignored without leaking a handle.
+	bi.SetUserData(BodyIDInvalid, "lost")
+	bi.SetUserData(id, "doomed")
+	bi.RemoveBody(id)
+	bi.DestroyBody(id)
+	if n := handles.len(); n != before {
+		t.Errorf("handles after DestroyBody = %d, want %d", n, before)
+	}
+	bi.SetUserData(id, "stale")
+	if n := handles.len(); n != before {
+		t.Errorf("SetUserData on a stale ID leaked a handle: %d handles, want %d", n, before)
+	}
+}
+
+func TestBodyUseAfterUnlockPanics(t *testing.T) {
+	b := &Body{id: 5} // handle 0: already unlocked
+	if b.GetID() != 5 {
+		t.Errorf("GetID should work without a lock, got %d", b.GetID())
+	}
+	b.Unlock() // no-op
+
+	defer func() {
+		if recover() == nil {
+			t.Error("GetPosition on an unlocked Body should panic")
+		}
+	}()
+	b.GetPosition()
+}
+
+func TestBodyWriteThroughReadLockPanics(t *testing.T) {
+	b := &Body{handle: 1}
+	defer func() {
+		if recover() == nil {
+			t.Error("SetLinearVelocity through a read lock should panic")
+		}
+	}()
+	b.SetLinearVelocity(Vec3{X: 1})
+}
+
+func TestBodyTypeConstants(t *testing.T) {
+	if BodyTypeRigid != 0 {
+		t.Errorf("BodyTypeRigid should be 0, got %d", BodyTypeRigid)
+	}
+	if BodyTypeSoft != 1 {
+		t.Errorf("BodyTypeSoft should be 1, got %d", BodyTypeSoft)
+	}
+}
+
+func TestBodyIDEncoding(t *testing.T) {
+	id := BodyID(0x05000123) // sequence 5, index 0x123
+	if id.Index() != 0x123 {
+		t.Errorf("Index should be 0x123, got %#x", id.Index())
+	}
+	if id.SequenceNumber() != 5 {
+		t.Errorf("SequenceNumber should be 5, got %d", id.SequenceNumber())
+	}
+	if id.IsInvalid() {
+		t.Error("valid ID reported as invalid")
+	}
+	if !BodyIDInvalid.IsInvalid() {
+		t.Error("BodyIDInvalid should report IsInvalid")
+	}
+	// The broad-phase bit (bit 23) is not part of the index.
+	if BodyID(0x00FFFFFF).Index() != 0x7FFFFF {
+		t.Errorf("Index should mask to 23 bits, got %#x", BodyID(0x00FFFFFF).Index())
+	}
+}
+
+func TestCheckedBodyInterfaceRejectsInvalidID(t *testing.T) {
+	c := (&BodyInterface{}).Checked()
+	if err := c.AddBody(BodyIDInvalid, Activate); !errors.Is(err, ErrInvalidBody) {
+		t.Errorf("AddBody(BodyIDInvalid) should return ErrInvalidBody, got %v", err)
+	}
+	if _, err := c.GetPosition(BodyIDInvalid); !errors.Is(err, ErrInvalidBody) {
+		t.Errorf("GetPosition(BodyIDInvalid) should return ErrInvalidBody, got %v", err)
+	}
+	if err := c.Unchecked().ValidateBodyID(BodyIDInvalid); !errors.Is(err, ErrInvalidBody) {
+		t.Errorf("ValidateBodyID(BodyIDInvalid) should return ErrInvalidBody, got %v", err)
+	}
+}
+
+func TestPhysicsSettingsRoundTrip(t *testing.T) {
+	ps := newTestSystem(t, 16)
+	defaults := ps.GetPhysicsSettings()
+
+	in := defaults
+	in.MaxInFlightBodyPairs = defaults.MaxInFlightBodyPairs / 2
+	in.StepListenersBatchSize = defaults.StepListenersBatchSize + 3
+	in.Baumgarte = 0.35
+	in.SpeculativeContactDistance = 0.05
+	in.PenetrationSlop = 0.01
+	in.NumVelocitySteps = defaults.NumVelocitySteps + 2
+	in.NumPositionSteps = defaults.NumPositionSteps + 1
+	in.MinVelocityForRestitution = 2.5
+	in.TimeBeforeSleep = 1.25
+	in.PointVelocitySleepThreshold = 0.07
+	in.DeterministicSimulation = !defaults.DeterministicSimulation
+	in.AllowSleeping = !defaults.AllowSleeping
+	in.CheckActiveEdges = !defaults.CheckActiveEdges
+	if in == defaults {
+		t.Fatal("test settings do not differ from the defaults")
+	}
+
+	ps.SetPhysicsSettings(in)
+	if out := ps.GetPhysicsSettings(); out != in {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", out, in)
+	}
+}
+
+var _ ContactListener = NopContactListener{}
+
+type removedRecorder struct {
+	NopContactListener
+	pairs []SubShapeIDPair
+}
+
+func (r *removedRecorder) OnContactRemoved(pair SubShapeIDPair) {
+	r.pairs = append(r.pairs, pair)
+}
+
+func TestContactListenerDispatch(t *testing.T) {
+	r := &removedRecorder{}
+	ref := contactListeners.add(r)
+	defer contactListeners.remove(ref)
+
+	pair := SubShapeIDPair{Body1ID: 1, SubShapeID1: 2, Body2ID: 3, SubShapeID2: 4}
+	onContactRemoved(uintptr(ref), &pair)
+	if len(r.pairs) != 1 || r.pairs[0] != pair {
+		t.Errorf("OnContactRemoved got %v, want [%v]", r.pairs, pair)
+	}
+
+	// Unknown listeners accept contacts and ignore notifications.
+	if got := onContactValidate(0, 0, 0, &Vec3{}, &collideShapeResult{}); got != uintptr(ValidateResultAcceptAllContacts) {
+		t.Errorf("onContactValidate without listener = %d, want %d", got, ValidateResultAcceptAllContacts)
+	}
+	onContactRemoved(0, &pair)
+}
+
+func TestContactManifoldAfterCallbackPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic when using a ContactManifold after its callback")
+		}
+	}()
+	(&ContactManifold{}).GetPenetrationDepth()
+}
+
+func TestContactSettingsAfterCallbackPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic when using ContactSettings after its callback")
+		}
+	}()
+	(&ContactSettings{}).SetFriction(0)
+}
+
+func TestContactEventsDrain(t *testing.T) {
+	c := NewContactEvents(2)
+	var l ContactListener = c
+	for i := range 3 {
+		l.OnContactRemoved(SubShapeIDPair{Body1ID: BodyID(i), Body2ID: BodyID(i + 10)})
+	}
+	if c.Len() != 2 || c.Dropped() != 1 {
+		t.Fatalf("Len, Dropped = %d, %d; want 2, 1", c.Len(), c.Dropped())
+	}
+	events := c.Drain(nil)
+	if len(events) != 2 || events[0].Body1 != 0 || events[1].Body2 != 11 || events[1].Type != ContactEnd {
+		t.Errorf("Drain returned %+v", events)
+	}
+	if c.Len() != 0 {
+		t.Errorf("Len after Drain = %d, want 0", c.Len())
+	}
+
+	l.OnContactRemoved(SubShapeIDPair{Body1ID: 5})
+	ch := make(chan ContactEvent, 1)
+	c.DrainTo(ch)
+	if e := <-ch; e.Body1 != 5 {
+		t.Errorf("DrainTo sent %+v", e)
+	}
+}
+
+func TestBodyActivationEventsDispatch(t *testing.T) {
+	c := NewBodyActivationEvents(4)
+	ref := bodyActivationListeners.add(c)
+	defer bodyActivationListeners.remove(ref)
+
+	data := handles.add("crate")
+	defer handles.remove(data)
+
+	onBodyActivated(uintptr(ref), 7, data)
+	onBodyDeactivated(uintptr(ref), 7, 0)
+	events := c.Drain(nil)
+	want := []BodyActivationEvent{
+		{BodyID: 7, Active: true, UserData: "crate"},
+		{BodyID: 7},
+	}
+	if len(events) != len(want) || events[0] != want[0] || events[1] != want[1] {
+		t.Errorf("events = %+v, want %+v", events, want)
+	}
+}
+
+func TestStepListenerOrder(t *testing.T) {
+	// A pre-populated listener set avoids creating the native listener.
+	s := &stepListeners{}
+	ps := &PhysicsSystem{stepListeners: s}
+	ref := stepListenerSets.add(s)
+	defer stepListenerSets.remove(ref)
+
+	var calls []string
+	var b *StepListener
+	ps.AddStepListener(func(ctx StepContext) {
+		calls = append(calls, "a")
+		ps.RemoveStepListener(b) // takes effect from the next step
+	})
+	b = ps.AddStepListener(func(ctx StepContext) { calls = append(calls, "b") })
+	ps.AddStepListener(func(ctx StepContext) {
+		if ctx.DeltaTime != 0.5 || !ctx.IsFirstStep || ctx.IsLastStep {
+			t.Errorf("unexpected context %+v", ctx)
+		}
+		calls = append(calls, "c")
+	})
+
+	context := physicsStepListenerContext{DeltaTime: 0.5, IsFirstStep: 1}
+	onStep(uintptr(ref), &context)
+	onStep(uintptr(ref), &context)
+	if got, want := fmt.Sprint(calls), "[a b c a c]"; got != want {
+		t.Errorf("calls = %s, want %s", got, want)
+	}
+}
+
+type teamLayers struct{}
+
+func (teamLayers) GetNumBroadPhaseLayers() uint32 { return 2 }
+
+func (teamLayers) GetBroadPhaseLayer(layer ObjectLayer) BroadPhaseLayer {
+	return BroadPhaseLayer(layer & 1)
+}
+
+func TestLayerCallbacksDispatch(t *testing.T) {
+	mapper := broadPhaseLayerMappers.add(teamLayers{})
+	defer broadPhaseLayerMappers.remove(mapper)
+	if n := getNumBroadPhaseLayers(uintptr(mapper)); n != 2 {
+		t.Errorf("GetNumBroadPhaseLayers = %d, want 2", n)
+	}
+	if bp := getBroadPhaseLayer(uintptr(mapper), 3); bp != 1 {
+		t.Errorf("GetBroadPhaseLayer(3) = %d, want 1", bp)
+	}
+
+	pair := objectLayerPairFilters.add(func(a, b ObjectLayer) bool { return a != b })
+	defer objectLayerPairFilters.remove(pair)
+	if objectLayerPairShouldCollide(uintptr(pair), 1, 1) != 0 || objectLayerPairShouldCollide(uintptr(pair), 1, 2) != 1 {
+		t.Error("ObjectLayerPairFilterFunc result not forwarded")
+	}
+
+	vsBP := objectVsBroadPhaseLayerFilters.add(func(l ObjectLayer, bp BroadPhaseLayer) bool { return bp == 0 })
+	defer objectVsBroadPhaseLayerFilters.remove(vsBP)
+	if objectVsBroadPhaseLayerShouldCollide(uintptr(vsBP), 5, 0) != 1 || objectVsBroadPhaseLayerShouldCollide(uintptr(vsBP), 5, 1) != 0 {
+		t.Error("ObjectVsBroadPhaseLayerFilterFunc result not forwarded")
+	}
+}
+
+func TestLayerCallbackDestroyReleasesSlot(t *testing.T) {
+	f := &ObjectLayerPairFilter{slot: objectLayerPairFilters.add(func(a, b ObjectLayer) bool { return true })}
+	slot := f.slot
+	f.Destroy()
+	if objectLayerPairFilters.get(uintptr(slot)) != nil {
+		t.Error("Destroy did not release the slot")
+	}
+	if objectLayerPairShouldCollide(uintptr(slot), 0, 0) != 0 {
+		t.Error("a released slot should not collide")
+	}
+	f.Destroy() // second Destroy is a no-op
+
+	// Table-based objects have no slot.
+	(&BroadPhaseLayerInterface{}).Destroy()
+	(&ObjectVsBroadPhaseLayerFilter{}).Destroy()
+}
+
+func TestSlotTableReusesSlots(t *testing.T) {
+	var table slotTable[string]
+	a, b := table.add("a"), table.add("b")
+	if a != 1 || b != 2 {
+		t.Fatalf("slots = %d, %d; want 1, 2", a, b)
+	}
+	table.remove(a)
+	if c := table.add("c"); c != a || table.get(uintptr(c)) != "c" || table.get(uintptr(b)) != "b" {
+		t.Errorf("slot %d not reused correctly", c)
+	}
+	if table.get(0) != "" || table.get(99) != "" {
+		t.Error("unknown slots should return the zero value")
+	}
+}
+
+func TestObjectLayerMask(t *testing.T) {
+	const (
+		groupPlayer uint16 = 1 << iota
+		groupEnemy
+		groupDebris
+	)
+	player := NewObjectLayerMask(groupPlayer, groupEnemy|groupDebris)
+	enemy := NewObjectLayerMask(groupEnemy, groupPlayer)
+	debris := NewObjectLayerMask(groupDebris, groupPlayer|groupDebris)
+
+	if player.Group() != groupPlayer || player.Mask() != groupEnemy|groupDebris {
+		t.Errorf("player Group, Mask = %b, %b", player.Group(), player.Mask())
+	}
+	if !player.CollidesWithMask(enemy) || !enemy.CollidesWithMask(player) {
+		t.Error("player and enemy should collide")
+	}
+	if enemy.CollidesWithMask(debris) {
+		t.Error("enemy and debris should not collide")
+	}
+	if !debris.CollidesWithMask(debris) {
+		t.Error("debris should collide with debris")
+	}
+}
+
+func TestLayerConfig(t *testing.T) {
+	var c LayerConfig
+	if c.AddObjectLayer("NON_MOVING") != 0 || c.AddObjectLayer("MOVING") != 1 {
+		t.Fatal("object layers should be numbered in order")
+	}
+	c.AddBroadPhaseLayer("STATIC")
+	c.AddBroadPhaseLayer("DYNAMIC")
+	c.MapObjectToBroadPhaseLayer("NON_MOVING", "STATIC")
+	c.MapObjectToBroadPhaseLayer("MOVING", "DYNAMIC")
+	c.EnableCollision("MOVING", "NON_MOVING")
+	c.EnableCollision("MOVING", "MOVING")
+	if err := c.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if !c.collide[0][1] || !c.collide[1][0] || c.collide[0][0] {
+		t.Errorf("collision matrix not symmetric: %v", c.collide)
+	}
+
+	want := "object layer broad-phase layer\n" +
+		"0 NON_MOVING 0 STATIC\n" +
+		"1 MOVING     1 DYNAMIC\n" +
+		"\n" +
+		"collides   NON_MOVING MOVING\n" +
+		"NON_MOVING .          x\n" +
+		"MOVING     x          x\n"
+	if got := c.String(); got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestLayerConfigValidate(t *testing.T) {
+	var c LayerConfig
+	c.AddObjectLayer("A")
+	c.AddObjectLayer("B")
+	c.AddBroadPhaseLayer("BP")
+	c.AddBroadPhaseLayer("UNUSED")
+	c.MapObjectToBroadPhaseLayer("A", "BP")
+	c.EnableCollision("A", "C")
+
+	err := c.Validate()
+	if err == nil {
+		t.Fatal("Validate should fail")
+	}
+	for _, msg := range []string{`unknown object layer "C"`, `object layer "B" is not mapped`, `broad-phase layer "UNUSED"`} {
+		if !strings.Contains(err.Error(), msg) {
+			t.Errorf("error %q does not mention %q", err, msg)
+		}
+	}
+	if _, _, _, err := c.Build(); err == nil {
+		t.Error("Build should fail for an invalid configuration")
+	}
+}
+
+func TestQueryFilterDispatch(t *testing.T) {
+	bp := queryFilterBroadPhaseLayerFuncs.add(func(layer BroadPhaseLayer) bool { return layer == 1 })
+	ol := queryFilterObjectLayerFuncs.add(func(layer ObjectLayer) bool { return layer != 2 })
+	body := queryFilterBodyFuncs.add(func(id BodyID) bool { return id != 42 })
+	defer queryFilterBroadPhaseLayerFuncs.remove(bp)
+	defer queryFilterObjectLayerFuncs.remove(ol)
+	defer queryFilterBodyFuncs.remove(body)
+
+	if broadPhaseLayerFilterShouldCollide(uintptr(bp), 1) != 1 || broadPhaseLayerFilterShouldCollide(uintptr(bp), 0) != 0 {
+		t.Error("broad-phase layer filter result not forwarded")
+	}
+	if objectLayerFilterShouldCollide(uintptr(ol), 2) != 0 || objectLayerFilterShouldCollide(uintptr(ol), 3) != 1 {
+		t.Error("object layer filter result not forwarded")
+	}
+	if bodyFilterShouldCollide(uintptr(body), 42) != 0 || bodyFilterShouldCollide(uintptr(body), 7) != 1 {
+		t.Error("body filter result not forwarded")
+	}
+}
+
+func TestQueryFilterNil(t *testing.T) {
+	var nilFilter *QueryFilter
+	if bp, ol, body := nilFilter.native(); bp != 0 || ol != 0 || body != 0 {
+		t.Errorf("nil QueryFilter passes filters %x, %x, %x", bp, ol, body)
+	}
+
+	// A filter without functions needs no C objects.
+	f := NewQueryFilter(QueryFilterFuncs{})
+	if *f != (QueryFilter{}) {
+		t.Errorf("empty QueryFilter created native filters: %+v", *f)
+	}
+	f.Destroy()
+}
+
+func TestQueryFilterDestroyReleasesSlots(t *testing.T) {
+	newTestSystem(t, 16) // loads joltc
+
+	f := NewQueryFilter(QueryFilterFuncs{
+		ObjectLayer: func(ObjectLayer) bool { return true },
+		Body:        func(BodyID) bool { return true },
+	})
+	if f.broadPhaseLayer != 0 || f.objectLayer == 0 || f.body == 0 {
+		t.Fatalf("C filters = %x, %x, %x; want only object layer and body", f.broadPhaseLayer, f.objectLayer, f.body)
+	}
+	olSlot, bodySlot := f.objectLayerSlot, f.bodySlot
+	if queryFilterObjectLayerFuncs.get(uintptr(olSlot)) == nil || queryFilterBodyFuncs.get(uintptr(bodySlot)) == nil {
+		t.Fatal("NewQueryFilter did not store its functions")
+	}
+
+	f.Destroy()
+	if queryFilterObjectLayerFuncs.get(uintptr(olSlot)) != nil || queryFilterBodyFuncs.get(uintptr(bodySlot)) != nil {
+		t.Error("Destroy did not release the functions")
+	}
+	if *f != (QueryFilter{}) {
+		t.Errorf("Destroy left %+v", *f)
+	}
+	f.Destroy() // second Destroy is a no-op
+}
+
+func TestRayPoint(t *testing.T) {
+	origin := Vec3{X: 1, Y: 2, Z: 3}
+	direction := Vec3{X: 0, Y: -10, Z: 4}
+	for _, tc := range []struct {
+		fraction float32
+		want     Vec3
+	}{
+		{0, origin},
+		{0.5, Vec3{X: 1, Y: -3, Z: 5}},
+		{1, Vec3{X: 1, Y: -8, Z: 7}},
+	} {
+		if got := rayPoint(origin, direction, tc.fraction); got != tc.want {
+			t.Errorf("rayPoint(%v) = %+v, want %+v", tc.fraction, got, tc.want)
+		}
+	}
+}
+
+func TestRayHitCollector(t *testing.T) {
+	if s := DefaultRayCastSettings(); s.BackFaceModeTriangles != BackFaceModeIgnoreBackFaces || !s.TreatConvexAsSolid {
+		t.Errorf("DefaultRayCastSettings() = %+v", s)
+	}
+
+	c := &RayHitCollector{Type: CollisionCollectorAllHitSorted, Hits: make([]RayHit, 0, 4)}
+	var collector RayCastCollector = c
+	if collector.CollectorType() != CollisionCollectorAllHitSorted {
+		t.Errorf("CollectorType() = %d", collector.CollectorType())
+	}
+	collector.AddHit(RayHit{BodyID: 1, Fraction: 0.25})
+	collector.AddHit(RayHit{BodyID: 2, Fraction: 0.5})
+	if len(c.Hits) != 2 || c.Hits[1].BodyID != 2 {
+		t.Errorf("Hits = %+v", c.Hits)
+	}
+}
+
+func TestRayCastSettingsToC(t *testing.T) {
+	var nilSettings *RayCastSettings
+	if got, want := nilSettings.toC(), (rayCastSettings{TreatConvexAsSolid: true}); got != want {
+		t.Errorf("nil settings = %+v, want defaults %+v", got, want)
+	}
+
+	s := &RayCastSettings{
+		BackFaceModeTriangles: BackFaceModeCollideWithBackFaces,
+		BackFaceModeConvex:    BackFaceModeIgnoreBackFaces,
+		TreatConvexAsSolid:    false,
+	}
+	want := rayCastSettings{BackFaceModeTriangles: 1, BackFaceModeConvex: 0, TreatConvexAsSolid: false}
+	if got := s.toC(); got != want {
+		t.Errorf("toC() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCastRayAllNilCollector(t *testing.T) {
+	// Returns before touching the (nil) query handle.
+	q := &NarrowPhaseQuery{}
+	if q.CastRayAll(RayCast{Direction: Vec3{Y: -1}}, nil, nil, nil) {
+		t.Error("CastRayAll with a nil collector reported a hit")
+	}
+}
+
+func TestOnCastRayResultUnknownHandle(t *testing.T) {
+	result := rayCastResult{BodyID: 1, Fraction: 0.5}
+	if r := onCastRayResult(0, &result); r != 0 {
+		t.Errorf("onCastRayResult = %d, want 0", r)
+	}
+}
+
+func TestOnCastRayResultDispatch(t *testing.T) {
+	ps := newTestSystem(t, 16)
+	id, err := ps.GetBodyInterface().Create(BodySpec{Shape: NewSphereShape(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collector := &RayHitCollector{Type: CollisionCollectorAllHit}
+	ray := RayCast{Origin: Vec3{Y: 5}, Direction: Vec3{Y: -10}}
+	ref := handles.add(&rayCastContext{query: ps.GetNarrowPhaseQuery(), ray: ray, collector: collector})
+	defer handles.remove(ref)
+
+	result := rayCastResult{BodyID: uint32(id), Fraction: 0.4, SubShapeID2: ^uint32(0)}
+	onCastRayResult(uintptr(ref), &result)
+	if len(collector.Hits) != 1 {
+		t.Fatalf("Hits = %+v, want one hit", collector.Hits)
+	}
+	hit := collector.Hits[0]
+	if hit.BodyID != id || hit.Fraction != 0.4 || hit.Point != (Vec3{Y: 1}) {
+		t.Errorf("hit = %+v", hit)
+	}
+	if hit.Normal.Y <= 0 {
+		t.Errorf("Normal = %+v, want pointing up", hit.Normal)
+	}
+}
+
+func TestCreatedBodyID(t *testing.T) {
+	if id, err := createdBodyID(uint32(BodyIDInvalid)); id != BodyIDInvalid || !errors.Is(err, ErrBodyLimitReached) {
+		t.Errorf("createdBodyID(invalid) = %v, %v; want BodyIDInvalid, ErrBodyLimitReached", id, err)
+	}
+	if id, err := createdBodyID(7); id != 7 || err != nil {
+		t.Errorf("createdBodyID(7) = %v, %v", id, err)
+	}
+}
+
+func TestBodyLimitReached(t *testing.T) {
+	ps := newTestSystem(t, 1)
+	bi := ps.GetBodyInterface()
+	shape := NewSphereShape(0.5)
+	settings := NewBodyCreationSettings(shape, Vec3{}, QuatIdentity(), MotionTypeStatic, 0)
+	defer settings.Close()
+
+	if _, err := bi.TryCreateAndAddBody(settings, DontActivate); err != nil {
+		t.Fatalf("first body: %v", err)
+	}
+	if id, err := bi.TryCreateAndAddBody(settings, DontActivate); !errors.Is(err, ErrBodyLimitReached) || id != BodyIDInvalid {
+		t.Errorf("TryCreateAndAddBody over the limit = %v, %v; want BodyIDInvalid, ErrBodyLimitReached", id, err)
+	}
+	if id := bi.CreateAndAddBody(settings, DontActivate); id != BodyIDInvalid {
+		t.Errorf("CreateAndAddBody over the limit = %v, want BodyIDInvalid", id)
+	}
+	if id, err := bi.CreateBody(settings); !errors.Is(err, ErrBodyLimitReached) || id != BodyIDInvalid {
+		t.Errorf("CreateBody over the limit = %v, %v; want BodyIDInvalid, ErrBodyLimitReached", id, err)
+	}
+}
+
+func TestAddBodiesBatchEmpty(t *testing.T) {
+	// Empty batches make no native calls, so a nil BodyInterface is fine.
+	var bi *BodyInterface
+	bi.PrepareAddBodies(nil).Finalize(Activate)
+	bi.PrepareAddBodies([]BodyID{}).Abort()
+	bi.AddBodies(nil, DontActivate)
+}
+
+// newBatchBodies creates n bodies that are not yet added to ps.
+func newBatchBodies(t *testing.T, ps *PhysicsSystem, n int) []BodyID {
+	t.Helper()
+	bi := ps.GetBodyInterface()
+	settings := NewBodyCreationSettings(NewSphereShape(0.5), Vec3{}, QuatIdentity(), MotionTypeDynamic, 0)
+	defer settings.Close()
+	ids := make([]BodyID, n)
+	for i := range ids {
+		id, err := bi.CreateBody(settings)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[i] = id
+	}
+	return ids
+}
+
+func TestAddBodiesBatchFinalize(t *testing.T) {
+	ps := newTestSystem(t, 16)
+	bi := ps.GetBodyInterface()
+	ids := newBatchBodies(t, ps, 3)
+
+	batch := bi.PrepareAddBodies(ids)
+	for _, id := range ids {
+		if bi.IsAdded(id) {
+			t.Fatalf("body %v added before Finalize", id)
+		}
+	}
+	batch.Finalize(Activate)
+	for _, id := range ids {
+		if !bi.IsAdded(id) || !bi.IsActive(id) {
+			t.Errorf("body %v not added and active after Finalize", id)
+		}
+	}
+
+	// Abort after Finalize is a no-op and must not remove the bodies.
+	batch.Abort()
+	for _, id := range ids {
+		if !bi.IsAdded(id) {
+			t.Errorf("Abort after Finalize removed body %v", id)
+		}
+	}
+}
+
+func TestAddBodiesBatchAbort(t *testing.T) {
+	ps := newTestSystem(t, 16)
+	bi := ps.GetBodyInterface()
+	ids := newBatchBodies(t, ps, 3)
+
+	bi.PrepareAddBodies(ids).Abort()
+	for _, id := range ids {
+		if bi.IsAdded(id) {
+			t.Errorf("aborted body %v was added", id)
+		}
+	}
+
+	// The bodies can be prepared again.
+	bi.AddBodies(ids, DontActivate)
+	for _, id := range ids {
+		if !bi.IsAdded(id) || bi.IsActive(id) {
+			t.Errorf("body %v not added inactive after AddBodies", id)
+		}
+	}
+}
+
+func TestBodyInterfaceVelocityAndForce(t *testing.T) {
+	ps := newTestSystem(t, 16)
+	bi := ps.GetBodyInterface()
+	newBody := func() BodyID {
+		t.Helper()
+		id, err := bi.Create(BodySpec{Shape: NewSphereShape(0.5), MotionType: MotionTypeDynamic, Activation: DontActivate})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+
+	id := newBody()
+	bi.SetAngularVelocity(id, Vec3{Y: 2})
+	if got := bi.GetAngularVelocity(id); got != (Vec3{Y: 2}) {
+		t.Errorf("GetAngularVelocity = %+v, want {Y: 2}", got)
+	}
+
+	bi.SetLinearAndAngularVelocity(id, Vec3{X: 1}, Vec3{Z: 1})
+	if lin, ang := bi.GetLinearVelocity(id), bi.GetAngularVelocity(id); lin != (Vec3{X: 1}) || ang != (Vec3{Z: 1}) {
+		t.Errorf("velocities = %+v, %+v; want {X: 1}, {Z: 1}", lin, ang)
+	}
+	// v = linear + angular x (point - center of mass)
+	if got := bi.GetPointVelocity(id, Vec3{X: 1}); got != (Vec3{X: 1, Y: 1}) {
+		t.Errorf("GetPointVelocity = %+v, want {X: 1, Y: 1}", got)
+	}
+
+	bi.SetPositionAndRotation(id, Vec3{Y: 3}, QuatIdentity(), DontActivate)
+	if pos, rot := bi.GetPosition(id), bi.GetRotation(id); pos != (Vec3{Y: 3}) || rot != QuatIdentity() {
+		t.Errorf("transform = %+v, %+v", pos, rot)
+	}
+
+	id = newBody()
+	bi.AddLinearVelocity(id, Vec3{X: 1})
+	bi.AddLinearVelocity(id, Vec3{X: 2})
+	if got := bi.GetLinearVelocity(id); got != (Vec3{X: 3}) {
+		t.Errorf("GetLinearVelocity after AddLinearVelocity = %+v, want {X: 3}", got)
+	}
+	if !bi.IsActive(id) {
+		t.Error("AddLinearVelocity did not activate the body")
+	}
+
+	id = newBody()
+	bi.SetPositionRotationAndVelocity(id, Vec3{Z: 5}, QuatIdentity(), Vec3{Y: 1}, Vec3{X: 1})
+	if pos, lin, ang := bi.GetPosition(id), bi.GetLinearVelocity(id), bi.GetAngularVelocity(id); pos != (Vec3{Z: 5}) || lin != (Vec3{Y: 1}) || ang != (Vec3{X: 1}) {
+		t.Errorf("SetPositionRotationAndVelocity stored %+v, %+v, %+v", pos, lin, ang)
+	}
+	if !bi.IsActive(id) {
+		t.Error("SetPositionRotationAndVelocity did not activate an added body")
+	}
+
+	id = newBody()
+	bi.AddTorque(id, Vec3{Y: 10})
+	if !bi.IsActive(id) {
+		t.Error("AddTorque did not activate the body")
+	}
+
+	id = newBody()
+	bi.AddForceAtPoint(id, Vec3{X: 10}, Vec3{Y: 0.5})
+	if !bi.IsActive(id) {
+		t.Error("AddForceAtPoint did not activate the body")
+	}
+}
+
+func containsBody(ids []BodyID, id BodyID) bool {
+	for _, other := range ids {
+		if other == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestBodiesIteration(t *testing.T) {
+	ps := newTestSystem(t, 16)
+	bi := ps.GetBodyInterface()
+	shape := NewSphereShape(0.5)
+	var ids []BodyID
+	for range 3 {
+		id, err := bi.Create(BodySpec{Shape: shape, MotionType: MotionTypeDynamic, Activation: DontActivate})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+
+	var seen []BodyID
+	for id := range ps.Bodies() {
+		seen = append(seen, id)
+	}
+	if len(seen) != 3 {
+		t.Fatalf("Bodies() = %v, want %v", seen, ids)
+	}
+	for _, id := range ids {
+		if !containsBody(seen, id) {
+			t.Errorf("Bodies() is missing %v", id)
+		}
+	}
+
+	// A removed body still exists; a destroyed one does not.
+	bi.RemoveBody(ids[0])
+	if !containsBody(ps.AppendBodies(nil), ids[0]) {
+		t.Error("removed body left AppendBodies")
+	}
+	bi.DestroyBody(ids[0])
+	if got := ps.AppendBodies(nil); containsBody(got, ids[0]) || len(got) != 2 {
+		t.Errorf("AppendBodies after DestroyBody = %v", got)
+	}
+
+	// AppendBodies keeps the existing elements of dst.
+	if got := ps.AppendBodies([]BodyID{99}); len(got) != 3 || got[0] != 99 {
+		t.Errorf("AppendBodies(dst) = %v", got)
+	}
+}
+
+func TestActiveBodies(t *testing.T) {
+	ps := newTestSystem(t, 16)
+	bi := ps.GetBodyInterface()
+	shape := NewSphereShape(0.5)
+	newBody := func(activation Activation) BodyID {
+		t.Helper()
+		id, err := bi.Create(BodySpec{Shape: shape, MotionType: MotionTypeDynamic, Activation: activation})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+	awake, sleeping := newBody(Activate), newBody(DontActivate)
+
+	if n := ps.GetNumActiveBodies(BodyTypeRigid); n != 1 {
+		t.Errorf("GetNumActiveBodies(BodyTypeRigid) = %d, want 1", n)
+	}
+	if n := ps.GetNumActiveBodies(BodyTypeSoft); n != 0 {
+		t.Errorf("GetNumActiveBodies(BodyTypeSoft) = %d, want 0", n)
+	}
+
+	active := ps.AppendActiveBodies(nil, BodyTypeRigid)
+	if len(active) != 1 || active[0] != awake {
+		t.Errorf("AppendActiveBodies(BodyTypeRigid) = %v, want [%v]", active, awake)
+	}
+	if containsBody(active, sleeping) {
+		t.Error("sleeping body reported as active")
+	}
+	if soft := ps.AppendActiveBodies(nil, BodyTypeSoft); len(soft) != 0 {
+		t.Errorf("AppendActiveBodies(BodyTypeSoft) = %v, want none", soft)
+	}
+
+	bi.ActivateBody(sleeping)
+	if got := ps.AppendActiveBodies([]BodyID{99}, BodyTypeRigid); len(got) != 3 || got[0] != 99 || !containsBody(got, sleeping) {
+		t.Errorf("AppendActiveBodies after ActivateBody = %v", got)
+	}
+	if n := ps.GetNumActiveBodies(BodyTypeRigid); n != 2 {
+		t.Errorf("GetNumActiveBodies(BodyTypeRigid) = %d, want 2", n)
+	}
+}
+
+func TestReadTransformsAndVelocities(t *testing.T) {
+	ps := newTestSystem(t, 16)
+	bi := ps.GetBodyInterface()
+	shape := NewSphereShape(0.5)
+	half := float32(math.Sqrt2 / 2)
+	specs := []BodySpec{
+		{Position: Vec3{X: 1, Y: 2, Z: 3}, LinearVelocity: Vec3{X: 1}, AngularVelocity: Vec3{Y: 0.5}},
+		{Position: Vec3{Y: -4}, Rotation: Quat{Y: half, W: half}, LinearVelocity: Vec3{Z: -2}},
+		{Position: Vec3{X: 7}, Rotation: Quat{X: half, W: half}, AngularVelocity: Vec3{X: 1, Z: 2}},
+	}
+	ids := make([]BodyID, len(specs))
+	for i, spec := range specs {
+		spec.Shape = shape
+		spec.MotionType = MotionTypeDynamic
+		spec.Activation = DontActivate
+		id, err := bi.Create(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[i] = id
+	}
+
+	pos, rot := make([]Vec3, len(ids)), make([]Quat, len(ids))
+	linear, angular := make([]Vec3, len(ids)), make([]Vec3, len(ids))
+	bi.ReadTransforms(ids, pos, rot)
+	bi.ReadVelocities(ids, linear, angular)
+	for i, spec := range specs {
+		wantRot := spec.Rotation
+		if wantRot == (Quat{}) {
+			wantRot = QuatIdentity()
+		}
+		if !vec3Near(pos[i], spec.Position) || !quatNear(rot[i], wantRot) {
+			t.Errorf("body %d transform = %+v, %+v; want %+v, %+v", i, pos[i], rot[i], spec.Position, wantRot)
+		}
+		if !vec3Near(linear[i], spec.LinearVelocity) || !vec3Near(angular[i], spec.AngularVelocity) {
+			t.Errorf("body %d velocity = %+v, %+v; want %+v, %+v", i, linear[i], angular[i], spec.LinearVelocity, spec.AngularVelocity)
+		}
+		// The bulk read matches the per-body getters.
+		if pos[i] != bi.GetPosition(ids[i]) || linear[i] != bi.GetLinearVelocity(ids[i]) {
+			t.Errorf("body %d bulk read differs from GetPosition/GetLinearVelocity", i)
+		}
+	}
+}
+
+func TestMassPropertiesOverride(t *testing.T) {
+	ps := newTestSystem(t, 16)
+	bi := ps.GetBodyInterface()
+	newBody := func(mode OverrideMassProperties, mp MassProperties) BodyID {
+		t.Helper()
+		settings := NewBodyCreationSettings(NewSphereShape(0.5), Vec3{}, QuatIdentity(), MotionTypeDynamic, 0)
+		defer settings.Close()
+		settings.SetOverrideMassProperties(mode)
+		settings.SetMassPropertiesOverride(mp)
+		if got := settings.GetMassPropertiesOverride(); got != mp {
+			t.Errorf("GetMassPropertiesOverride = %+v, want %+v", got, mp)
+		}
+		id, err := bi.TryCreateAndAddBody(settings, DontActivate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+	diagonal := func(m Matrix4x4) Vec3 { return Vec3{X: m.M11, Y: m.M22, Z: m.M33} }
+
+	// Mass and inertia provided: the body uses them as given.
+	id := newBody(OverrideMassPropertiesMassAndInertiaProvided, MassProperties{
+		Mass:    4,
+		Inertia: Matrix4x4{M11: 2, M22: 4, M33: 8, M44: 1},
+	})
+	if got := bi.GetInverseMass(id); !near(got, 0.25) {
+		t.Errorf("GetInverseMass = %v, want 0.25", got)
+	}
+	if got := diagonal(bi.GetInverseInertia(id)); !vec3Near(got, Vec3{X: 0.5, Y: 0.25, Z: 0.125}) {
+		t.Errorf("GetInverseInertia diagonal = %+v, want {0.5 0.25 0.125}", got)
+	}
+
+	// Only the mass provided: the sphere's inertia 2/5*m*r^2 = 1 is scaled
+	// to it.
+	id = newBody(OverrideMassPropertiesCalculateInertia, MassProperties{Mass: 10})
+	if got := bi.GetInverseMass(id); !near(got, 0.1) {
+		t.Errorf("GetInverseMass = %v, want 0.1", got)
+	}
+	if got := diagonal(bi.GetInverseInertia(id)); !vec3Near(got, Vec3{X: 1, Y: 1, Z: 1}) {
+		t.Errorf("GetInverseInertia diagonal = %+v, want {1 1 1}", got)
+	}
+}
+
+func TestBodyObjectLayerAndCollisionGroup(t *testing.T) {
+	ps := newTestSystem(t, 16)
+	bi := ps.GetBodyInterface()
+	id, err := bi.Create(BodySpec{Shape: NewSphereShape(0.5), MotionType: MotionTypeDynamic})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if layer := bi.GetObjectLayer(id); layer != 0 {
+		t.Errorf("GetObjectLayer = %d, want 0", layer)
+	}
+	bi.SetObjectLayer(id, 1)
+	if layer := bi.GetObjectLayer(id); layer != 1 {
+		t.Errorf("GetObjectLayer after SetObjectLayer = %d, want 1", layer)
+	}
+
+	if g := bi.GetCollisionGroup(id); g.GroupFilter != nil || g.GroupID != CollisionGroupIDInvalid || g.SubGroupID != CollisionSubGroupIDInvalid {
+		t.Errorf("default collision group = %+v", g)
+	}
+	filter := NewGroupFilterTable(4)
+	defer filter.Destroy()
+	bi.SetCollisionGroup(id, CollisionGroup{GroupFilter: filter, GroupID: 3, SubGroupID: 2})
+	g := bi.GetCollisionGroup(id)
+	if g.GroupFilter == nil || g.GroupFilter.handle != filter.handle || g.GroupID != 3 || g.SubGroupID != 2 {
+		t.Errorf("GetCollisionGroup = %+v, want filter %#x, group 3, sub-group 2", g, filter.handle)
+	}
+
+	// An invalid ID reads back as no group.
+	if g := bi.GetCollisionGroup(BodyIDInvalid); g.GroupID != CollisionGroupIDInvalid || g.GroupFilter != nil {
+		t.Errorf("GetCollisionGroup(BodyIDInvalid) = %+v", g)
+	}
+}
+
+func TestLockBody(t *testing.T) {
+	ps := newTestSystem(t, 16)
+	bi := ps.GetBodyInterface()
+	id, err := bi.Create(BodySpec{
+		Shape:          NewSphereShape(0.5),
+		Position:       Vec3{Y: 2},
+		MotionType:     MotionTypeDynamic,
+		ObjectLayer:    1,
+		LinearVelocity: Vec3{X: 3},
+		Activation:     Activate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	read := ps.LockBodyRead(id)
+	if read == nil {
+		t.Fatal("LockBodyRead returned nil for a live body")
+	}
+	if read.GetID() != id || read.GetPosition() != (Vec3{Y: 2}) || read.GetLinearVelocity() != (Vec3{X: 3}) ||
+		read.GetObjectLayer() != 1 || !read.IsDynamic() || !read.IsActive() || read.GetBodyType() != BodyTypeRigid {
+		t.Errorf("read accessors: id %v, position %+v, velocity %+v, layer %d",
+			read.GetID(), read.GetPosition(), read.GetLinearVelocity(), read.GetObjectLayer())
+	}
+	read.Unlock()
+
+	write := ps.LockBodyWrite(id)
+	if write == nil {
+		t.Fatal("LockBodyWrite returned nil for a live body")
+	}
+	write.SetLinearVelocity(Vec3{Z: -1})
+	write.SetIsSensor(true)
+	write.Unlock()
+	write.Unlock() // second Unlock is a no-op
+	if got := bi.GetLinearVelocity(id); got != (Vec3{Z: -1}) {
+		t.Errorf("velocity after write lock = %+v, want {Z: -1}", got)
+	}
+	if b := ps.LockBodyRead(id); b == nil || !b.IsSensor() {
+		t.Error("SetIsSensor under a write lock was not kept")
+	} else {
+		b.Unlock()
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("using a Body after Unlock should panic")
+			}
+		}()
+		read.GetPosition()
+	}()
+
+	if b := ps.LockBodyRead(BodyIDInvalid); b != nil {
+		t.Error("LockBodyRead(BodyIDInvalid) should return nil")
+	}
+	bi.RemoveBody(id)
+	bi.DestroyBody(id)
+	if b := ps.LockBodyWrite(id); b != nil {
+		t.Error("LockBodyWrite of a destroyed body should return nil")
+	}
+}
+
+func TestCastRay(t *testing.T) {
+	ps := newTestSystem(t, 16)
+	id, err := ps.GetBodyInterface().Create(BodySpec{Shape: NewSphereShape(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := ps.GetNarrowPhaseQuery()
+	origin, direction := Vec3{Y: 5}, Vec3{Y: -10}
+
+	hit, ok := q.CastRay(origin, direction, nil)
+	if !ok {
+		t.Fatal("CastRay missed the sphere")
+	}
+	if hit.BodyID != id || !near(hit.Fraction, 0.4) {
+		t.Errorf("hit = %+v, want body %v at fraction 0.4", hit, id)
+	}
+	if !vec3Near(hit.Point, Vec3{Y: 1}) || !vec3Near(hit.Normal, Vec3{Y: 1}) {
+		t.Errorf("hit point %+v, normal %+v; want {Y: 1} for both", hit.Point, hit.Normal)
+	}
+
+	if hit, ok := q.CastRay(Vec3{X: 5, Y: 5}, direction, nil); ok {
+		t.Errorf("ray beside the sphere hit %+v", hit)
+	}
+
+	ignore := NewQueryFilter(QueryFilterFuncs{Body: func(b BodyID) bool { return b != id }})
+	defer ignore.Destroy()
+	if hit, ok := q.CastRay(origin, direction, ignore); ok {
+		t.Errorf("filtered ray hit %+v", hit)
+	}
+	accept := NewQueryFilter(QueryFilterFuncs{ObjectLayer: func(l ObjectLayer) bool { return l == 0 }})
+	defer accept.Destroy()
+	if hit, ok := q.CastRay(origin, direction, accept); !ok || hit.BodyID != id {
+		t.Errorf("CastRay with an accepting filter = %+v, %v", hit, ok)
+	}
+}
+
+func TestPhysicsSystemCloseReleasesUserData(t *testing.T) {
+	ps := newTestSystem(t, 16)
+	bi := ps.GetBodyInterface()
+	id, err := bi.Create(BodySpec{Shape: NewSphereShape(0.5), MotionType: MotionTypeDynamic})
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := handles.len()
+	bi.SetUserData(id, "player")
+	if handles.len() != before+1 {
+		t.Fatalf("SetUserData did not add a handle")
+	}
+	ps.Close()
+	if n := handles.len(); n != before {
+		t.Errorf("handles after Close = %d, want %d", n, before)
+	}
+}
+
+func TestKeyframeZeroRotationIsIdentity(t *testing.T) {
+	track := KeyframeTrack{Keyframes: []Keyframe{
+		{Time: 0},
+		{Time: 1, Position: Vec3{X: 1}},
+	}}
+	for _, time := range []float32{0, 0.5, 1} {
+		_, rot := track.Sample(time)
+		if rot != QuatIdentity() {
+			t.Errorf("Sample(%v) rotation = %+v, want identity", time, rot)
+		}
+	}
+	if q := slerpQuat(Quat{}, Quat{}, 0.5); q != QuatIdentity() {
+		t.Errorf("slerp of zero quaternions = %+v, want identity", q)
+	}
+}
+
+func TestKinematicPlayerStepIgnoresNonPositiveDelta(t *testing.T) {
+	track := &KeyframeTrack{Keyframes: []Keyframe{{Time: 0}, {Time: 1}}}
+	// A nil BodyInterface would panic if MoveKinematic were called.
+	p := NewKinematicPlayer(nil, 1, track)
+	p.Step(0)
+	p.Step(-1)
+	if p.Time() != 0 {
+		t.Errorf("Time() = %v after non-positive steps, want 0", p.Time())
+	}
+}
+
+func TestKinematicPlayerWrapsTime(t *testing.T) {
+	for _, tc := range []struct {
+		mode   TrackMode
+		period float32
+	}{{TrackLoop, 2}, {TrackPingPong, 4}} {
+		p := NewKinematicPlayer(nil, 1, &KeyframeTrack{
+			Keyframes: []Keyframe{{Time: 0}, {Time: 2}},
+			Mode:      tc.mode,
+		})
+		for range 1000 {
+			p.advance(0.75)
+		}
+		if p.Time() < 0 || p.Time() >= tc.period {
+			t.Errorf("mode %d: Time() = %v, want within [0, %v)", tc.mode, p.Time(), tc.period)
+		}
+		if want := float32(math.Mod(750, float64(tc.period))); math.Abs(float64(p.Time()-want)) > 1e-3 {
+			t.Errorf("mode %d: Time() = %v, want %v", tc.mode, p.Time(), want)
+		}
+	}
+}
+
+func TestReadbackPanicsOnShortSlices(t *testing.T) {
+	bi := &BodyInterface{}
+	ids := []BodyID{1, 2}
+	for name, read := range map[string]func(){
+		"ReadTransforms": func() { bi.ReadTransforms(ids, make([]Vec3, 2), make([]Quat, 1)) },
+		"ReadVelocities": func() { bi.ReadVelocities(ids, make([]Vec3, 1), make([]Vec3, 2)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s should panic when a destination is shorter than ids", name)
+				}
+			}()
+			read()
+		}()
+	}
+}
@@ -0,0 +1,234 @@
+package jolt
+
+import (
+	"math"
+	"sort"
+)
+
+// Keyframe is a target pose at a point in time on a KeyframeTrack.
+type Keyframe struct {
+	Time     float32 // seconds from the start of the track
+	Position Vec3
+	Rotation Quat // the zero value is treated as QuatIdentity
+}
+
+// TrackMode controls what happens when playback reaches the end of a track.
+type TrackMode int32
+
+const (
+	// TrackOnce holds the last keyframe once the end is reached.
+	TrackOnce TrackMode = 0
+	// TrackLoop jumps back to the first keyframe and repeats.
+	TrackLoop TrackMode = 1
+	// TrackPingPong plays the track forward, then backward, and repeats.
+	TrackPingPong TrackMode = 2
+)
+
+// KeyframeTrack is a time-based curve of poses, used to animate kinematic
+// bodies such as doors, elevators and moving platforms.
+// Positions are interpolated linearly and rotations spherically.
+type KeyframeTrack struct {
+	// Keyframes must be sorted by ascending Time.
+	Keyframes []Keyframe
+	// Mode selects the end-of-track behavior.
+	Mode TrackMode
+	// Ease remaps the 0..1 progress between two keyframes. Nil means linear;
+	// see EaseInOut.
+	Ease func(t float32) float32
+}
+
+// EaseInOut is a smoothstep easing curve that starts and ends each segment
+// at zero velocity.
+func EaseInOut(t float32) float32 {
+	return t * t * (3 - 2*t)
+}
+
+// Duration returns the time of the last keyframe.
+func (t *KeyframeTrack) Duration() float32 {
+	if len(t.Keyframes) == 0 {
+		return 0
+	}
+	return t.Keyframes[len(t.Keyframes)-1].Time
+}
+
+// Sample returns the pose of the track at the given time, taking Mode into
+// account. An empty track returns the origin and identity rotation.
+func (t *KeyframeTrack) Sample(time float32) (Vec3, Quat) {
+	keys := t.Keyframes
+	if len(keys) == 0 {
+		return Vec3{}, QuatIdentity()
+	}
+	time = t.wrapTime(time)
+
+	// Index of the first keyframe strictly after time.
+	i := sort.Search(len(keys), func(i int) bool { return keys[i].Time > time })
+	if i == 0 {
+		return keys[0].Position, keyRotation(keys[0])
+	}
+	if i == len(keys) {
+		last := keys[len(keys)-1]
+		return last.Position, keyRotation(last)
+	}
+
+	a, b := keys[i-1], keys[i]
+	f := (time - a.Time) / (b.Time - a.Time)
+	if t.Ease != nil {
+		f = t.Ease(f)
+	}
+	return lerpVec3(a.Position, b.Position, f), slerpQuat(keyRotation(a), keyRotation(b), f)
+}
+
+// keyRotation returns the rotation of k. Like BodySpec.Rotation, the zero
+// value is treated as QuatIdentity.
+func keyRotation(k Keyframe) Quat {
+	if k.Rotation == (Quat{}) {
+		return QuatIdentity()
+	}
+	return k.Rotation
+}
+
+// wrapTime maps time onto [0, Duration] according to Mode.
+func (t *KeyframeTrack) wrapTime(time float32) float32 {
+	d := t.Duration()
+	if d <= 0 {
+		return 0
+	}
+	switch t.Mode {
+	case TrackLoop:
+		time = wrapPeriod(time, d)
+	case TrackPingPong:
+		time = wrapPeriod(time, 2*d)
+		if time > d {
+			time = 2*d - time
+		}
+	}
+	return time
+}
+
+// KinematicPlayer drives a kinematic body along a KeyframeTrack using
+// BodyInterface.MoveKinematic, so that bodies resting on it are carried
+// along instead of sliding off.
+type KinematicPlayer struct {
+	bi     *BodyInterface
+	bodyID BodyID
+	track  *KeyframeTrack
+	time   float32
+
+	// Speed scales playback; 1 is real time, negative plays backward.
+	Speed float32
+	// Paused stops playback; the body is held at its current pose.
+	Paused bool
+}
+
+// NewKinematicPlayer creates a player that moves bodyID along track,
+// starting at time 0. The body should have MotionTypeKinematic.
+func NewKinematicPlayer(bi *BodyInterface, bodyID BodyID, track *KeyframeTrack) *KinematicPlayer {
+	return &KinematicPlayer{bi: bi, bodyID: bodyID, track: track, Speed: 1}
+}
+
+// Time returns the current playback time in seconds. For TrackLoop and
+// TrackPingPong it stays within one period of the track.
+func (p *KinematicPlayer) Time() float32 {
+	return p.time
+}
+
+// Seek sets the playback time. The body moves there during the next Step.
+func (p *KinematicPlayer) Seek(time float32) {
+	p.time = time
+}
+
+// Done reports whether a TrackOnce track has finished playing.
+func (p *KinematicPlayer) Done() bool {
+	if p.track.Mode != TrackOnce {
+		return false
+	}
+	if p.Speed < 0 {
+		return p.time <= 0
+	}
+	return p.time >= p.track.Duration()
+}
+
+// Step advances playback by deltaTime and sets the body's velocity so that it
+// reaches the new pose at the end of the next physics step. Call it once per
+// PhysicsSystem.Update with the same deltaTime. A deltaTime of zero or less
+// does nothing, since no velocity can reach a pose in no time.
+func (p *KinematicPlayer) Step(deltaTime float32) {
+	if deltaTime <= 0 {
+		return
+	}
+	p.advance(deltaTime)
+	pos, rot := p.track.Sample(p.time)
+	p.bi.MoveKinematic(p.bodyID, pos, rot, deltaTime)
+}
+
+// advance moves the playback time by deltaTime. Looping tracks keep the time
+// within one period, so that float32 precision does not degrade over long
+// sessions.
+func (p *KinematicPlayer) advance(deltaTime float32) {
+	if p.Paused {
+		return
+	}
+	p.time += deltaTime * p.Speed
+	d := p.track.Duration()
+	switch p.track.Mode {
+	case TrackOnce:
+		p.time = max(0, min(p.time, d))
+	case TrackLoop:
+		p.time = wrapPeriod(p.time, d)
+	case TrackPingPong:
+		p.time = wrapPeriod(p.time, 2*d)
+	}
+}
+
+// wrapPeriod maps time onto [0, period). A non-positive period yields 0.
+func wrapPeriod(time, period float32) float32 {
+	if period <= 0 {
+		return 0
+	}
+	time = float32(math.Mod(float64(time), float64(period)))
+	if time < 0 {
+		time += period
+	}
+	return time
+}
+
+func lerpVec3(a, b Vec3, t float32) Vec3 {
+	return Vec3{
+		X: a.X + (b.X-a.X)*t,
+		Y: a.Y + (b.Y-a.Y)*t,
+		Z: a.Z + (b.Z-a.Z)*t,
+	}
+}
+
+// slerpQuat spherically interpolates between two unit quaternions along the
+// shortest arc.
+func slerpQuat(a, b Quat, t float32) Quat {
+	dot := float64(a.X*b.X + a.Y*b.Y + a.Z*b.Z + a.W*b.W)
+	if dot < 0 {
+		b = Quat{X: -b.X, Y: -b.Y, Z: -b.Z, W: -b.W}
+		dot = -dot
+	}
+
+	var wa, wb float64
+	if dot > 0.9995 {
+		// Nearly parallel: fall back to normalized linear interpolation.
+		wa, wb = 1-float64(t), float64(t)
+	} else {
+		theta := math.Acos(dot)
+		sin := math.Sin(theta)
+		wa = math.Sin((1-float64(t))*theta) / sin
+		wb = math.Sin(float64(t)*theta) / sin
+	}
+
+	q := Quat{
+		X: float32(wa*float64(a.X) + wb*float64(b.X)),
+		Y: float32(wa*float64(a.Y) + wb*float64(b.Y)),
+		Z: float32(wa*float64(a.Z) + wb*float64(b.Z)),
+		W: float32(wa*float64(a.W) + wb*float64(b.W)),
+	}
+	n := float32(math.Sqrt(float64(q.X*q.X + q.Y*q.Y + q.Z*q.Z + q.W*q.W)))
+	if n == 0 {
+		return QuatIdentity()
+	}
+	return Quat{X: q.X / n, Y: q.Y / n, Z: q.Z / n, W: q.W / n}
+}
@@ -0,0 +1,181 @@
+package jolt
+
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/ebitengine/purego"
+)
+
+// BroadPhaseLayerMapper maps object layers to broad-phase layers with Go
+// code instead of a table, for example when an ObjectLayer packs several
+// fields into its bits. Use it with NewBroadPhaseLayerInterface.
+//
+// The methods are called from Jolt's worker threads and must be safe for
+// concurrent use. They are called often, so they should be fast and must
+// always return the same result for the same input.
+type BroadPhaseLayerMapper interface {
+	// GetNumBroadPhaseLayers returns the number of broad-phase layers.
+	GetNumBroadPhaseLayers() uint32
+	// GetBroadPhaseLayer returns the broad-phase layer of an object layer.
+	// It must be less than GetNumBroadPhaseLayers.
+	GetBroadPhaseLayer(layer ObjectLayer) BroadPhaseLayer
+}
+
+// ObjectLayerPairFilterFunc reports whether objects in two object layers may
+// collide. Use it with NewObjectLayerPairFilter. Like a BroadPhaseLayerMapper,
+// it is called from Jolt's worker threads and must be fast, safe for
+// concurrent use, and symmetric.
+type ObjectLayerPairFilterFunc func(layer1, layer2 ObjectLayer) bool
+
+// ObjectVsBroadPhaseLayerFilterFunc reports whether objects in an object
+// layer may collide with objects in a broad-phase layer. Use it with
+// NewObjectVsBroadPhaseLayerFilter. It is called from Jolt's worker threads
+// and must be fast and safe for concurrent use.
+type ObjectVsBroadPhaseLayerFilterFunc func(layer ObjectLayer, bpLayer BroadPhaseLayer) bool
+
+// Go values of callback-based layer objects, looked up without locking by
+// the callbacks.
+var (
+	broadPhaseLayerMappers         slotTable[BroadPhaseLayerMapper]
+	objectLayerPairFilters         slotTable[ObjectLayerPairFilterFunc]
+	objectVsBroadPhaseLayerFilters slotTable[ObjectVsBroadPhaseLayerFilterFunc]
+)
+
+// NewBroadPhaseLayerInterface creates a broad-phase layer interface that
+// calls m. It can be used in PhysicsSystemConfig in place of a table-based
+// interface. Call Destroy after closing the PhysicsSystem that uses it.
+func NewBroadPhaseLayerInterface(m BroadPhaseLayerMapper) *BroadPhaseLayerInterface {
+	registerLayerCallbackProcs()
+	slot := broadPhaseLayerMappers.add(m)
+	h := jphBroadPhaseLayerInterfaceCreate(uintptr(slot))
+	return &BroadPhaseLayerInterface{handle: h, slot: slot}
+}
+
+// NewObjectLayerPairFilter creates an object layer pair filter that calls fn.
+// It can be used in PhysicsSystemConfig in place of a table-based filter.
+// Call Destroy after closing the PhysicsSystem that uses it.
+func NewObjectLayerPairFilter(fn ObjectLayerPairFilterFunc) *ObjectLayerPairFilter {
+	registerLayerCallbackProcs()
+	slot := objectLayerPairFilters.add(fn)
+	h := jphObjectLayerPairFilterCreate(uintptr(slot))
+	return &ObjectLayerPairFilter{handle: h, slot: slot}
+}
+
+// NewObjectVsBroadPhaseLayerFilter creates an object vs broad-phase layer
+// filter that calls fn. It can be used in PhysicsSystemConfig in place of a
+// table-based filter. Call Destroy after closing the PhysicsSystem that uses
+// it.
+func NewObjectVsBroadPhaseLayerFilter(fn ObjectVsBroadPhaseLayerFilterFunc) *ObjectVsBroadPhaseLayerFilter {
+	registerLayerCallbackProcs()
+	slot := objectVsBroadPhaseLayerFilters.add(fn)
+	h := jphObjectVsBroadPhaseLayerFilterCreate(uintptr(slot))
+	return &ObjectVsBroadPhaseLayerFilter{handle: h, slot: slot}
+}
+
+// Destroy releases the Go mapper of an interface created by
+// NewBroadPhaseLayerInterface. The PhysicsSystem using it must be closed
+// first. It is a no-op for table and mask based interfaces, whose C memory
+// is owned by the PhysicsSystem.
+func (b *BroadPhaseLayerInterface) Destroy() {
+	broadPhaseLayerMappers.remove(b.slot)
+	b.slot = 0
+}
+
+// Destroy releases the Go function of a filter created by
+// NewObjectLayerPairFilter. The PhysicsSystem using it must be closed first.
+// It is a no-op for table and mask based filters.
+func (f *ObjectLayerPairFilter) Destroy() {
+	objectLayerPairFilters.remove(f.slot)
+	f.slot = 0
+}
+
+// Destroy releases the Go function of a filter created by
+// NewObjectVsBroadPhaseLayerFilter. The PhysicsSystem using it must be closed
+// first. It is a no-op for table and mask based filters.
+func (f *ObjectVsBroadPhaseLayerFilter) Destroy() {
+	objectVsBroadPhaseLayerFilters.remove(f.slot)
+	f.slot = 0
+}
+
+// broadPhaseLayerInterfaceProcs mirrors the C struct
+// JPH_BroadPhaseLayerInterface_Procs.
+type broadPhaseLayerInterfaceProcs struct {
+	GetNumBroadPhaseLayers uintptr
+	GetBroadPhaseLayer     uintptr
+	GetBroadPhaseLayerName uintptr
+}
+
+// objectLayerPairFilterProcs mirrors the C struct
+// JPH_ObjectLayerPairFilter_Procs.
+type objectLayerPairFilterProcs struct {
+	ShouldCollide uintptr
+}
+
+// objectVsBroadPhaseLayerFilterProcs mirrors the C struct
+// JPH_ObjectVsBroadPhaseLayerFilter_Procs.
+type objectVsBroadPhaseLayerFilterProcs struct {
+	ShouldCollide uintptr
+}
+
+var layerCallbackProcsOnce sync.Once
+
+// registerLayerCallbackProcs installs the Go callbacks shared by all
+// callback-based layer interfaces and filters, as
+// registerContactListenerProcs does for contacts.
+func registerLayerCallbackProcs() {
+	layerCallbackProcsOnce.Do(func() {
+		jphBroadPhaseLayerInterfaceSetProcs(&broadPhaseLayerInterfaceProcs{
+			GetNumBroadPhaseLayers: purego.NewCallback(getNumBroadPhaseLayers),
+			GetBroadPhaseLayer:     purego.NewCallback(getBroadPhaseLayer),
+			GetBroadPhaseLayerName: purego.NewCallback(getBroadPhaseLayerName),
+		})
+		jphObjectLayerPairFilterSetProcs(&objectLayerPairFilterProcs{
+			ShouldCollide: purego.NewCallback(objectLayerPairShouldCollide),
+		})
+		jphObjectVsBroadPhaseLayerFilterSetProcs(&objectVsBroadPhaseLayerFilterProcs{
+			ShouldCollide: purego.NewCallback(objectVsBroadPhaseLayerShouldCollide),
+		})
+	})
+}
+
+// broadPhaseLayerName is the name reported for every callback-based
+// broad-phase layer. Jolt only uses it for debug output.
+var broadPhaseLayerName = []byte("BroadPhaseLayer\x00")
+
+func boolResult(b bool) uintptr {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func getNumBroadPhaseLayers(userData uintptr) uintptr {
+	m := broadPhaseLayerMappers.get(userData)
+	if m == nil {
+		return 0
+	}
+	return uintptr(m.GetNumBroadPhaseLayers())
+}
+
+func getBroadPhaseLayer(userData uintptr, layer uint32) uintptr {
+	m := broadPhaseLayerMappers.get(userData)
+	if m == nil {
+		return 0
+	}
+	return uintptr(m.GetBroadPhaseLayer(ObjectLayer(layer)))
+}
+
+func getBroadPhaseLayerName(userData uintptr, layer uint8) uintptr {
+	return uintptr(unsafe.Pointer(&broadPhaseLayerName[0]))
+}
+
+func objectLayerPairShouldCollide(userData uintptr, layer1, layer2 uint32) uintptr {
+	fn := objectLayerPairFilters.get(userData)
+	return boolResult(fn != nil && fn(ObjectLayer(layer1), ObjectLayer(layer2)))
+}
+
+func objectVsBroadPhaseLayerShouldCollide(userData uintptr, layer uint32, bpLayer uint8) uintptr {
+	fn := objectVsBroadPhaseLayerFilters.get(userData)
+	return boolResult(fn != nil && fn(ObjectLayer(layer), BroadPhaseLayer(bpLayer)))
+}
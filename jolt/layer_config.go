@@ -0,0 +1,200 @@
+package jolt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// LayerConfig declares named object layers, named broad-phase layers and the
+// object layer collision matrix, and builds the three table-based objects a
+// PhysicsSystemConfig needs with consistent layer counts:
+//
+//	var layers jolt.LayerConfig
+//	layers.AddObjectLayer("NON_MOVING") // ObjectLayer 0
+//	layers.AddObjectLayer("MOVING")     // ObjectLayer 1
+//	layers.AddBroadPhaseLayer("NON_MOVING")
+//	layers.AddBroadPhaseLayer("MOVING")
+//	layers.MapObjectToBroadPhaseLayer("NON_MOVING", "NON_MOVING")
+//	layers.MapObjectToBroadPhaseLayer("MOVING", "MOVING")
+//	layers.EnableCollision("MOVING", "NON_MOVING")
+//	layers.EnableCollision("MOVING", "MOVING")
+//	bp, pairs, objVsBP, err := layers.Build()
+//
+// Collisions are symmetric. Mistakes such as unknown names are reported by
+// Build. The zero value is an empty configuration.
+type LayerConfig struct {
+	objectLayers []string
+	bpLayers     []string
+	mapping      []int    // broad-phase layer per object layer, -1 if unmapped
+	collide      [][]bool // indexed by object layer, kept symmetric
+	errs         []error
+}
+
+// AddObjectLayer adds an object layer and returns its ObjectLayer, which is
+// assigned in order starting at 0.
+func (c *LayerConfig) AddObjectLayer(name string) ObjectLayer {
+	if indexOf(c.objectLayers, name) >= 0 {
+		c.errs = append(c.errs, fmt.Errorf("jolt: duplicate object layer %q", name))
+	}
+	c.objectLayers = append(c.objectLayers, name)
+	c.mapping = append(c.mapping, -1)
+	for i := range c.collide {
+		c.collide[i] = append(c.collide[i], false)
+	}
+	c.collide = append(c.collide, make([]bool, len(c.objectLayers)))
+	return ObjectLayer(len(c.objectLayers) - 1)
+}
+
+// AddBroadPhaseLayer adds a broad-phase layer and returns its
+// BroadPhaseLayer, which is assigned in order starting at 0.
+func (c *LayerConfig) AddBroadPhaseLayer(name string) BroadPhaseLayer {
+	if indexOf(c.bpLayers, name) >= 0 {
+		c.errs = append(c.errs, fmt.Errorf("jolt: duplicate broad-phase layer %q", name))
+	}
+	if len(c.bpLayers) == 256 {
+		c.errs = append(c.errs, fmt.Errorf("jolt: too many broad-phase layers adding %q", name))
+	}
+	c.bpLayers = append(c.bpLayers, name)
+	return BroadPhaseLayer(len(c.bpLayers) - 1)
+}
+
+// MapObjectToBroadPhaseLayer puts objects in the named object layer into the
+// named broad-phase layer.
+func (c *LayerConfig) MapObjectToBroadPhaseLayer(objectLayer, broadPhaseLayer string) {
+	ol, bp := c.objectLayerIndex(objectLayer), c.bpLayerIndex(broadPhaseLayer)
+	if ol >= 0 && bp >= 0 {
+		c.mapping[ol] = bp
+	}
+}
+
+// EnableCollision lets objects in the two named object layers collide, in
+// both directions. The layers may be the same.
+func (c *LayerConfig) EnableCollision(layer1, layer2 string) {
+	c.setCollision(layer1, layer2, true)
+}
+
+// DisableCollision prevents objects in the two named object layers from
+// colliding, in both directions.
+func (c *LayerConfig) DisableCollision(layer1, layer2 string) {
+	c.setCollision(layer1, layer2, false)
+}
+
+func (c *LayerConfig) setCollision(layer1, layer2 string, enable bool) {
+	a, b := c.objectLayerIndex(layer1), c.objectLayerIndex(layer2)
+	if a >= 0 && b >= 0 {
+		c.collide[a][b] = enable
+		c.collide[b][a] = enable
+	}
+}
+
+func (c *LayerConfig) objectLayerIndex(name string) int {
+	i := indexOf(c.objectLayers, name)
+	if i < 0 {
+		c.errs = append(c.errs, fmt.Errorf("jolt: unknown object layer %q", name))
+	}
+	return i
+}
+
+func (c *LayerConfig) bpLayerIndex(name string) int {
+	i := indexOf(c.bpLayers, name)
+	if i < 0 {
+		c.errs = append(c.errs, fmt.Errorf("jolt: unknown broad-phase layer %q", name))
+	}
+	return i
+}
+
+func indexOf(names []string, name string) int {
+	for i, n := range names {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Validate reports all mistakes in the configuration: unknown or duplicate
+// names, object layers without a broad-phase layer, and broad-phase layers
+// without any object layer.
+func (c *LayerConfig) Validate() error {
+	errs := append([]error(nil), c.errs...)
+	if len(c.objectLayers) == 0 {
+		errs = append(errs, errors.New("jolt: no object layers"))
+	}
+	used := make([]bool, len(c.bpLayers))
+	for i, bp := range c.mapping {
+		if bp < 0 {
+			errs = append(errs, fmt.Errorf("jolt: object layer %q is not mapped to a broad-phase layer", c.objectLayers[i]))
+			continue
+		}
+		used[bp] = true
+	}
+	for i, u := range used {
+		if !u {
+			errs = append(errs, fmt.Errorf("jolt: no object layer is mapped to broad-phase layer %q", c.bpLayers[i]))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Build validates the configuration and creates the broad-phase layer
+// interface, object layer pair filter and object vs broad-phase layer filter
+// to use in a PhysicsSystemConfig.
+func (c *LayerConfig) Build() (*BroadPhaseLayerInterface, *ObjectLayerPairFilter, *ObjectVsBroadPhaseLayerFilter, error) {
+	if err := c.Validate(); err != nil {
+		return nil, nil, nil, err
+	}
+	numObjectLayers, numBPLayers := uint32(len(c.objectLayers)), uint32(len(c.bpLayers))
+
+	bp := NewBroadPhaseLayerInterfaceTable(numObjectLayers, numBPLayers)
+	for ol, bpl := range c.mapping {
+		bp.MapObjectToBroadPhaseLayer(ObjectLayer(ol), BroadPhaseLayer(bpl))
+	}
+	pairs := NewObjectLayerPairFilterTable(numObjectLayers)
+	for a, row := range c.collide {
+		for b := a; b < len(row); b++ {
+			if row[b] {
+				pairs.EnableCollision(ObjectLayer(a), ObjectLayer(b))
+			}
+		}
+	}
+	objVsBP := NewObjectVsBroadPhaseLayerFilterTable(bp, numBPLayers, pairs, numObjectLayers)
+	return bp, pairs, objVsBP, nil
+}
+
+// String returns a table of the layers, their broad-phase mapping and the
+// collision matrix, for debugging.
+func (c *LayerConfig) String() string {
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 1, ' ', 0)
+
+	fmt.Fprintln(w, "object layer\tbroad-phase layer")
+	for i, name := range c.objectLayers {
+		bp := "-"
+		if c.mapping[i] >= 0 {
+			bp = fmt.Sprintf("%d %s", c.mapping[i], c.bpLayers[c.mapping[i]])
+		}
+		fmt.Fprintf(w, "%d %s\t%s\n", i, name, bp)
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprint(w, "collides")
+	for _, name := range c.objectLayers {
+		fmt.Fprintf(w, "\t%s", name)
+	}
+	fmt.Fprintln(w)
+	for i, row := range c.collide {
+		fmt.Fprint(w, c.objectLayers[i])
+		for _, on := range row {
+			if on {
+				fmt.Fprint(w, "\tx")
+			} else {
+				fmt.Fprint(w, "\t.")
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+	return sb.String()
+}
@@ -0,0 +1,60 @@
+package jolt
+
+// In mask mode an ObjectLayer packs a collision group in its low 16 bits and
+// a collision mask in its high 16 bits. Two objects collide if the group of
+// each intersects the mask of the other, so any number of layer combinations
+// can be expressed without a collision table.
+
+const objectLayerMaskShift = 16
+
+// NewObjectLayerMask returns the ObjectLayer for a group and mask in mask
+// mode. group holds the bits of the groups the object belongs to and mask
+// the bits of the groups it collides with.
+func NewObjectLayerMask(group, mask uint16) ObjectLayer {
+	return ObjectLayer(uint32(group) | uint32(mask)<<objectLayerMaskShift)
+}
+
+// Group returns the group bits of a mask mode ObjectLayer.
+func (l ObjectLayer) Group() uint16 {
+	return uint16(l)
+}
+
+// Mask returns the mask bits of a mask mode ObjectLayer.
+func (l ObjectLayer) Mask() uint16 {
+	return uint16(l >> objectLayerMaskShift)
+}
+
+// CollidesWithMask reports whether objects in l and other collide under the
+// rule used by NewObjectLayerPairFilterMask.
+func (l ObjectLayer) CollidesWithMask(other ObjectLayer) bool {
+	return l.Group()&other.Mask() != 0 && other.Group()&l.Mask() != 0
+}
+
+// NewObjectLayerPairFilterMask creates an object layer pair filter for mask
+// mode: layers built with NewObjectLayerMask collide if CollidesWithMask
+// reports true.
+func NewObjectLayerPairFilterMask() *ObjectLayerPairFilter {
+	return &ObjectLayerPairFilter{handle: jphObjectLayerPairFilterMaskCreate()}
+}
+
+// NewBroadPhaseLayerInterfaceMask creates a broad-phase layer interface for
+// mask mode. Assign groups to broad-phase layers with ConfigureLayer.
+func NewBroadPhaseLayerInterfaceMask(numBroadPhaseLayers uint32) *BroadPhaseLayerInterface {
+	return &BroadPhaseLayerInterface{handle: jphBroadPhaseLayerInterfaceMaskCreate(numBroadPhaseLayers)}
+}
+
+// ConfigureLayer sets which objects go into a broad-phase layer of an
+// interface created by NewBroadPhaseLayerInterfaceMask. An object goes into
+// the first layer whose groupsToInclude shares a bit with its group while
+// groupsToExclude shares none; objects matching no layer go into the last
+// layer.
+func (b *BroadPhaseLayerInterface) ConfigureLayer(broadPhaseLayer BroadPhaseLayer, groupsToInclude, groupsToExclude uint16) {
+	jphBroadPhaseLayerInterfaceMaskConfigureLayer(b.handle, uint8(broadPhaseLayer), uint32(groupsToInclude), uint32(groupsToExclude))
+}
+
+// NewObjectVsBroadPhaseLayerFilterMask creates an object vs broad-phase layer
+// filter for mask mode, using the mapping of bpInterface, which must have been
+// created with NewBroadPhaseLayerInterfaceMask.
+func NewObjectVsBroadPhaseLayerFilterMask(bpInterface *BroadPhaseLayerInterface) *ObjectVsBroadPhaseLayerFilter {
+	return &ObjectVsBroadPhaseLayerFilter{handle: jphObjectVsBroadPhaseLayerFilterMaskCreate(bpInterface.handle)}
+}
@@ -30,8 +30,8 @@ func getLibraryName() string {
 // used by this wrapper. It is called once from Init().
 func loadLibrary() error {
 	joltDll := windows.NewLazyDLL(getLibraryName())
-	if joltDll == nil {
-		return fmt.Errorf("jolt: failed to load joltc library (%s)", getLibraryName())
+	if err := joltDll.Load(); err != nil {
+		return fmt.Errorf("jolt: failed to load joltc library (%s): %w", getLibraryName(), err)
 	}
 	lib = joltDll.Handle()
 	registerSymbols(joltDll.Handle())
@@ -52,14 +52,24 @@ func registerSymbols(handle uintptr) {
 	// --- BroadPhaseLayerInterface ---
 	purego.RegisterLibFunc(&jphBroadPhaseLayerInterfaceTableCreate, handle, "JPH_BroadPhaseLayerInterfaceTable_Create")
 	purego.RegisterLibFunc(&jphBroadPhaseLayerInterfaceTableMapObjectToBroadPhaseLayer, handle, "JPH_BroadPhaseLayerInterfaceTable_MapObjectToBroadPhaseLayer")
+	purego.RegisterLibFunc(&jphBroadPhaseLayerInterfaceMaskCreate, handle, "JPH_BroadPhaseLayerInterfaceMask_Create")
+	purego.RegisterLibFunc(&jphBroadPhaseLayerInterfaceMaskConfigureLayer, handle, "JPH_BroadPhaseLayerInterfaceMask_ConfigureLayer")
+	purego.RegisterLibFunc(&jphBroadPhaseLayerInterfaceSetProcs, handle, "JPH_BroadPhaseLayerInterface_SetProcs")
+	purego.RegisterLibFunc(&jphBroadPhaseLayerInterfaceCreate, handle, "JPH_BroadPhaseLayerInterface_Create")
 
 	// --- ObjectLayerPairFilter ---
 	purego.RegisterLibFunc(&jphObjectLayerPairFilterTableCreate, handle, "JPH_ObjectLayerPairFilterTable_Create")
 	purego.RegisterLibFunc(&jphObjectLayerPairFilterTableEnableCollision, handle, "JPH_ObjectLayerPairFilterTable_EnableCollision")
 	purego.RegisterLibFunc(&jphObjectLayerPairFilterTableDisableCollision, handle, "JPH_ObjectLayerPairFilterTable_DisableCollision")
+	purego.RegisterLibFunc(&jphObjectLayerPairFilterMaskCreate, handle, "JPH_ObjectLayerPairFilterMask_Create")
+	purego.RegisterLibFunc(&jphObjectLayerPairFilterSetProcs, handle, "JPH_ObjectLayerPairFilter_SetProcs")
+	purego.RegisterLibFunc(&jphObjectLayerPairFilterCreate, handle, "JPH_ObjectLayerPairFilter_Create")
 
 	// --- ObjectVsBroadPhaseLayerFilter ---
 	purego.RegisterLibFunc(&jphObjectVsBroadPhaseLayerFilterTableCreate, handle, "JPH_ObjectVsBroadPhaseLayerFilterTable_Create")
+	purego.RegisterLibFunc(&jphObjectVsBroadPhaseLayerFilterMaskCreate, handle, "JPH_ObjectVsBroadPhaseLayerFilterMask_Create")
+	purego.RegisterLibFunc(&jphObjectVsBroadPhaseLayerFilterSetProcs, handle, "JPH_ObjectVsBroadPhaseLayerFilter_SetProcs")
+	purego.RegisterLibFunc(&jphObjectVsBroadPhaseLayerFilterCreate, handle, "JPH_ObjectVsBroadPhaseLayerFilter_Create")
 
 	// --- PhysicsSystem ---
 	purego.RegisterLibFunc(&jphPhysicsSystemCreate, handle, "JPH_PhysicsSystem_Create")
@@ -72,6 +82,137 @@ func registerSymbols(handle uintptr) {
 	purego.RegisterLibFunc(&jphPhysicsSystemGetNumBodies, handle, "JPH_PhysicsSystem_GetNumBodies")
 	purego.RegisterLibFunc(&jphPhysicsSystemGetNumActiveBodies, handle, "JPH_PhysicsSystem_GetNumActiveBodies")
 	purego.RegisterLibFunc(&jphPhysicsSystemGetMaxBodies, handle, "JPH_PhysicsSystem_GetMaxBodies")
+	purego.RegisterLibFunc(&jphPhysicsSystemGetPhysicsSettings, handle, "JPH_PhysicsSystem_GetPhysicsSettings")
+	purego.RegisterLibFunc(&jphPhysicsSystemSetPhysicsSettings, handle, "JPH_PhysicsSystem_SetPhysicsSettings")
+	purego.RegisterLibFunc(&jphPhysicsSystemGetBodies, handle, "JPH_PhysicsSystem_GetBodies")
+	purego.RegisterLibFunc(&jphPhysicsSystemGetBodyLockInterface, handle, "JPH_PhysicsSystem_GetBodyLockInterface")
+
+	// --- BodyLockInterface ---
+	purego.RegisterLibFunc(&jphBodyLockInterfaceLockRead, handle, "JPH_BodyLockInterface_LockRead")
+	purego.RegisterLibFunc(&jphBodyLockInterfaceUnlockRead, handle, "JPH_BodyLockInterface_UnlockRead")
+	purego.RegisterLibFunc(&jphBodyLockInterfaceLockWrite, handle, "JPH_BodyLockInterface_LockWrite")
+	purego.RegisterLibFunc(&jphBodyLockInterfaceUnlockWrite, handle, "JPH_BodyLockInterface_UnlockWrite")
+
+	// --- ContactListener ---
+	purego.RegisterLibFunc(&jphContactListenerSetProcs, handle, "JPH_ContactListener_SetProcs")
+	purego.RegisterLibFunc(&jphContactListenerCreate, handle, "JPH_ContactListener_Create")
+	purego.RegisterLibFunc(&jphContactListenerDestroy, handle, "JPH_ContactListener_Destroy")
+	purego.RegisterLibFunc(&jphPhysicsSystemSetContactListener, handle, "JPH_PhysicsSystem_SetContactListener")
+
+	// --- BodyActivationListener ---
+	purego.RegisterLibFunc(&jphBodyActivationListenerSetProcs, handle, "JPH_BodyActivationListener_SetProcs")
+	purego.RegisterLibFunc(&jphBodyActivationListenerCreate, handle, "JPH_BodyActivationListener_Create")
+	purego.RegisterLibFunc(&jphBodyActivationListenerDestroy, handle, "JPH_BodyActivationListener_Destroy")
+	purego.RegisterLibFunc(&jphPhysicsSystemSetBodyActivationListener, handle, "JPH_PhysicsSystem_SetBodyActivationListener")
+
+	// --- PhysicsStepListener ---
+	purego.RegisterLibFunc(&jphPhysicsStepListenerSetProcs, handle, "JPH_PhysicsStepListener_SetProcs")
+	purego.RegisterLibFunc(&jphPhysicsStepListenerCreate, handle, "JPH_PhysicsStepListener_Create")
+	purego.RegisterLibFunc(&jphPhysicsStepListenerDestroy, handle, "JPH_PhysicsStepListener_Destroy")
+	purego.RegisterLibFunc(&jphPhysicsSystemAddStepListener, handle, "JPH_PhysicsSystem_AddStepListener")
+	purego.RegisterLibFunc(&jphPhysicsSystemGetBodyInterfaceNoLock, handle, "JPH_PhysicsSystem_GetBodyInterfaceNoLock")
+	purego.RegisterLibFunc(&jphPhysicsSystemGetBodyLockInterfaceNoLock, handle, "JPH_PhysicsSystem_GetBodyLockInterfaceNoLock")
+
+	// --- Query filters ---
+	purego.RegisterLibFunc(&jphBroadPhaseLayerFilterSetProcs, handle, "JPH_BroadPhaseLayerFilter_SetProcs")
+	purego.RegisterLibFunc(&jphBroadPhaseLayerFilterCreate, handle, "JPH_BroadPhaseLayerFilter_Create")
+	purego.RegisterLibFunc(&jphBroadPhaseLayerFilterDestroy, handle, "JPH_BroadPhaseLayerFilter_Destroy")
+	purego.RegisterLibFunc(&jphObjectLayerFilterSetProcs, handle, "JPH_ObjectLayerFilter_SetProcs")
+	purego.RegisterLibFunc(&jphObjectLayerFilterCreate, handle, "JPH_ObjectLayerFilter_Create")
+	purego.RegisterLibFunc(&jphObjectLayerFilterDestroy, handle, "JPH_ObjectLayerFilter_Destroy")
+	purego.RegisterLibFunc(&jphBodyFilterSetProcs, handle, "JPH_BodyFilter_SetProcs")
+	purego.RegisterLibFunc(&jphBodyFilterCreate, handle, "JPH_BodyFilter_Create")
+	purego.RegisterLibFunc(&jphBodyFilterDestroy, handle, "JPH_BodyFilter_Destroy")
+
+	// --- NarrowPhaseQuery ---
+	purego.RegisterLibFunc(&jphPhysicsSystemGetNarrowPhaseQuery, handle, "JPH_PhysicsSystem_GetNarrowPhaseQuery")
+	purego.RegisterLibFunc(&jphNarrowPhaseQueryCastRay, handle, "JPH_NarrowPhaseQuery_CastRay")
+	purego.RegisterLibFunc(&jphNarrowPhaseQueryCastRay3, handle, "JPH_NarrowPhaseQuery_CastRay3")
+
+	// --- ContactManifold ---
+	purego.RegisterLibFunc(&jphContactManifoldGetWorldSpaceNormal, handle, "JPH_ContactManifold_GetWorldSpaceNormal")
+	purego.RegisterLibFunc(&jphContactManifoldGetPenetrationDepth, handle, "JPH_ContactManifold_GetPenetrationDepth")
+	purego.RegisterLibFunc(&jphContactManifoldGetSubShapeID1, handle, "JPH_ContactManifold_GetSubShapeID1")
+	purego.RegisterLibFunc(&jphContactManifoldGetSubShapeID2, handle, "JPH_ContactManifold_GetSubShapeID2")
+	purego.RegisterLibFunc(&jphContactManifoldGetPointCount, handle, "JPH_ContactManifold_GetPointCount")
+	purego.RegisterLibFunc(&jphContactManifoldGetWorldSpaceContactPointOn1, handle, "JPH_ContactManifold_GetWorldSpaceContactPointOn1")
+	purego.RegisterLibFunc(&jphContactManifoldGetWorldSpaceContactPointOn2, handle, "JPH_ContactManifold_GetWorldSpaceContactPointOn2")
+
+	// --- ContactSettings ---
+	purego.RegisterLibFunc(&jphContactSettingsGetFriction, handle, "JPH_ContactSettings_GetFriction")
+	purego.RegisterLibFunc(&jphContactSettingsSetFriction, handle, "JPH_ContactSettings_SetFriction")
+	purego.RegisterLibFunc(&jphContactSettingsGetRestitution, handle, "JPH_ContactSettings_GetRestitution")
+	purego.RegisterLibFunc(&jphContactSettingsSetRestitution, handle, "JPH_ContactSettings_SetRestitution")
+	purego.RegisterLibFunc(&jphContactSettingsGetInvMassScale1, handle, "JPH_ContactSettings_GetInvMassScale1")
+	purego.RegisterLibFunc(&jphContactSettingsSetInvMassScale1, handle, "JPH_ContactSettings_SetInvMassScale1")
+	purego.RegisterLibFunc(&jphContactSettingsGetInvInertiaScale1, handle, "JPH_ContactSettings_GetInvInertiaScale1")
+	purego.RegisterLibFunc(&jphContactSettingsSetInvInertiaScale1, handle, "JPH_ContactSettings_SetInvInertiaScale1")
+	purego.RegisterLibFunc(&jphContactSettingsGetInvMassScale2, handle, "JPH_ContactSettings_GetInvMassScale2")
+	purego.RegisterLibFunc(&jphContactSettingsSetInvMassScale2, handle, "JPH_ContactSettings_SetInvMassScale2")
+	purego.RegisterLibFunc(&jphContactSettingsGetInvInertiaScale2, handle, "JPH_ContactSettings_GetInvInertiaScale2")
+	purego.RegisterLibFunc(&jphContactSettingsSetInvInertiaScale2, handle, "JPH_ContactSettings_SetInvInertiaScale2")
+	purego.RegisterLibFunc(&jphContactSettingsGetIsSensor, handle, "JPH_ContactSettings_GetIsSensor")
+	purego.RegisterLibFunc(&jphContactSettingsSetIsSensor, handle, "JPH_ContactSettings_SetIsSensor")
+	purego.RegisterLibFunc(&jphContactSettingsGetRelativeLinearSurfaceVelocity, handle, "JPH_ContactSettings_GetRelativeLinearSurfaceVelocity")
+	purego.RegisterLibFunc(&jphContactSettingsSetRelativeLinearSurfaceVelocity, handle, "JPH_ContactSettings_SetRelativeLinearSurfaceVelocity")
+	purego.RegisterLibFunc(&jphContactSettingsGetRelativeAngularSurfaceVelocity, handle, "JPH_ContactSettings_GetRelativeAngularSurfaceVelocity")
+	purego.RegisterLibFunc(&jphContactSettingsSetRelativeAngularSurfaceVelocity, handle, "JPH_ContactSettings_SetRelativeAngularSurfaceVelocity")
+
+	// --- Body ---
+	purego.RegisterLibFunc(&jphBodyGetID, handle, "JPH_Body_GetID")
+	purego.RegisterLibFunc(&jphBodyGetWorldSpaceSurfaceNormal, handle, "JPH_Body_GetWorldSpaceSurfaceNormal")
+	purego.RegisterLibFunc(&jphBodyGetMotionProperties, handle, "JPH_Body_GetMotionProperties")
+	purego.RegisterLibFunc(&jphBodyGetPosition, handle, "JPH_Body_GetPosition")
+	purego.RegisterLibFunc(&jphBodyGetRotation, handle, "JPH_Body_GetRotation")
+	purego.RegisterLibFunc(&jphBodyGetCenterOfMassPosition, handle, "JPH_Body_GetCenterOfMassPosition")
+	purego.RegisterLibFunc(&jphBodyGetLinearVelocity, handle, "JPH_Body_GetLinearVelocity")
+	purego.RegisterLibFunc(&jphBodySetLinearVelocity, handle, "JPH_Body_SetLinearVelocity")
+	purego.RegisterLibFunc(&jphBodyGetAngularVelocity, handle, "JPH_Body_GetAngularVelocity")
+	purego.RegisterLibFunc(&jphBodySetAngularVelocity, handle, "JPH_Body_SetAngularVelocity")
+	purego.RegisterLibFunc(&jphBodyAddForce, handle, "JPH_Body_AddForce")
+	purego.RegisterLibFunc(&jphBodyAddTorque, handle, "JPH_Body_AddTorque")
+	purego.RegisterLibFunc(&jphBodyAddImpulse, handle, "JPH_Body_AddImpulse")
+	purego.RegisterLibFunc(&jphBodyGetShape, handle, "JPH_Body_GetShape")
+	purego.RegisterLibFunc(&jphBodyGetMotionType, handle, "JPH_Body_GetMotionType")
+	purego.RegisterLibFunc(&jphBodyGetObjectLayer, handle, "JPH_Body_GetObjectLayer")
+	purego.RegisterLibFunc(&jphBodyGetFriction, handle, "JPH_Body_GetFriction")
+	purego.RegisterLibFunc(&jphBodyGetRestitution, handle, "JPH_Body_GetRestitution")
+	purego.RegisterLibFunc(&jphBodyGetUserData, handle, "JPH_Body_GetUserData")
+	purego.RegisterLibFunc(&jphBodyIsSensor, handle, "JPH_Body_IsSensor")
+	purego.RegisterLibFunc(&jphBodySetIsSensor, handle, "JPH_Body_SetIsSensor")
+	purego.RegisterLibFunc(&jphBodyIsActive, handle, "JPH_Body_IsActive")
+	purego.RegisterLibFunc(&jphBodyIsInBroadPhase, handle, "JPH_Body_IsInBroadPhase")
+	purego.RegisterLibFunc(&jphBodyGetBodyType, handle, "JPH_Body_GetBodyType")
+	purego.RegisterLibFunc(&jphBodyIsStatic, handle, "JPH_Body_IsStatic")
+	purego.RegisterLibFunc(&jphBodyIsKinematic, handle, "JPH_Body_IsKinematic")
+	purego.RegisterLibFunc(&jphBodyIsDynamic, handle, "JPH_Body_IsDynamic")
+	purego.RegisterLibFunc(&jphBodyGetAllowSleeping, handle, "JPH_Body_GetAllowSleeping")
+	purego.RegisterLibFunc(&jphBodySetAllowSleeping, handle, "JPH_Body_SetAllowSleeping")
+	purego.RegisterLibFunc(&jphBodyResetSleepTimer, handle, "JPH_Body_ResetSleepTimer")
+	purego.RegisterLibFunc(&jphBodyGetWorldSpaceBounds, handle, "JPH_Body_GetWorldSpaceBounds")
+	purego.RegisterLibFunc(&jphBodyGetCollisionGroup, handle, "JPH_Body_GetCollisionGroup")
+	purego.RegisterLibFunc(&jphBodySetCollisionGroup, handle, "JPH_Body_SetCollisionGroup")
+
+	// --- GroupFilter ---
+	purego.RegisterLibFunc(&jphGroupFilterDestroy, handle, "JPH_GroupFilter_Destroy")
+	purego.RegisterLibFunc(&jphGroupFilterCanCollide, handle, "JPH_GroupFilter_CanCollide")
+	purego.RegisterLibFunc(&jphGroupFilterTableCreate, handle, "JPH_GroupFilterTable_Create")
+	purego.RegisterLibFunc(&jphGroupFilterTableEnableCollision, handle, "JPH_GroupFilterTable_EnableCollision")
+	purego.RegisterLibFunc(&jphGroupFilterTableDisableCollision, handle, "JPH_GroupFilterTable_DisableCollision")
+	purego.RegisterLibFunc(&jphGroupFilterTableIsCollisionEnabled, handle, "JPH_GroupFilterTable_IsCollisionEnabled")
+
+	// --- MotionProperties ---
+	purego.RegisterLibFunc(&jphMotionPropertiesGetInverseMassUnchecked, handle, "JPH_MotionProperties_GetInverseMassUnchecked")
+	purego.RegisterLibFunc(&jphMotionPropertiesGetInverseInertiaDiagonal, handle, "JPH_MotionProperties_GetInverseInertiaDiagonal")
+	purego.RegisterLibFunc(&jphMotionPropertiesGetInertiaRotation, handle, "JPH_MotionProperties_GetInertiaRotation")
+	purego.RegisterLibFunc(&jphMotionPropertiesGetLinearDamping, handle, "JPH_MotionProperties_GetLinearDamping")
+	purego.RegisterLibFunc(&jphMotionPropertiesSetLinearDamping, handle, "JPH_MotionProperties_SetLinearDamping")
+	purego.RegisterLibFunc(&jphMotionPropertiesGetAngularDamping, handle, "JPH_MotionProperties_GetAngularDamping")
+	purego.RegisterLibFunc(&jphMotionPropertiesSetAngularDamping, handle, "JPH_MotionProperties_SetAngularDamping")
+	purego.RegisterLibFunc(&jphMotionPropertiesGetAllowedDOFs, handle, "JPH_MotionProperties_GetAllowedDOFs")
+
+	// --- MassProperties ---
+	purego.RegisterLibFunc(&jphMassPropertiesScaleToMass, handle, "JPH_MassProperties_ScaleToMass")
 
 	// --- Shapes ---
 	purego.RegisterLibFunc(&jphBoxShapeCreate, handle, "JPH_BoxShape_Create")
@@ -80,12 +221,16 @@ func registerSymbols(handle uintptr) {
 	purego.RegisterLibFunc(&jphSphereShapeGetRadius, handle, "JPH_SphereShape_GetRadius")
 	purego.RegisterLibFunc(&jphCapsuleShapeCreate, handle, "JPH_CapsuleShape_Create")
 	purego.RegisterLibFunc(&jphShapeDestroy, handle, "JPH_Shape_Destroy")
+	purego.RegisterLibFunc(&jphShapeAddRef, handle, "JPH_Shape_AddRef")
+	purego.RegisterLibFunc(&jphShapeGetMassProperties, handle, "JPH_Shape_GetMassProperties")
 
 	// --- BodyCreationSettings ---
 	purego.RegisterLibFunc(&jphBodyCreationSettingsCreate3, handle, "JPH_BodyCreationSettings_Create3")
 	purego.RegisterLibFunc(&jphBodyCreationSettingsDestroy, handle, "JPH_BodyCreationSettings_Destroy")
 	purego.RegisterLibFunc(&jphBodyCreationSettingsSetLinearVelocity, handle, "JPH_BodyCreationSettings_SetLinearVelocity")
 	purego.RegisterLibFunc(&jphBodyCreationSettingsGetLinearVelocity, handle, "JPH_BodyCreationSettings_GetLinearVelocity")
+	purego.RegisterLibFunc(&jphBodyCreationSettingsSetAngularVelocity, handle, "JPH_BodyCreationSettings_SetAngularVelocity")
+	purego.RegisterLibFunc(&jphBodyCreationSettingsGetAngularVelocity, handle, "JPH_BodyCreationSettings_GetAngularVelocity")
 	purego.RegisterLibFunc(&jphBodyCreationSettingsSetFriction, handle, "JPH_BodyCreationSettings_SetFriction")
 	purego.RegisterLibFunc(&jphBodyCreationSettingsGetFriction, handle, "JPH_BodyCreationSettings_GetFriction")
 	purego.RegisterLibFunc(&jphBodyCreationSettingsSetRestitution, handle, "JPH_BodyCreationSettings_SetRestitution")
@@ -96,9 +241,20 @@ func registerSymbols(handle uintptr) {
 	purego.RegisterLibFunc(&jphBodyCreationSettingsGetAllowSleeping, handle, "JPH_BodyCreationSettings_GetAllowSleeping")
 	purego.RegisterLibFunc(&jphBodyCreationSettingsSetMotionQuality, handle, "JPH_BodyCreationSettings_SetMotionQuality")
 	purego.RegisterLibFunc(&jphBodyCreationSettingsGetMotionQuality, handle, "JPH_BodyCreationSettings_GetMotionQuality")
+	purego.RegisterLibFunc(&jphBodyCreationSettingsSetCollisionGroup, handle, "JPH_BodyCreationSettings_SetCollisionGroup")
+	purego.RegisterLibFunc(&jphBodyCreationSettingsGetCollisionGroup, handle, "JPH_BodyCreationSettings_GetCollisionGroup")
+	purego.RegisterLibFunc(&jphBodyCreationSettingsSetOverrideMassProperties, handle, "JPH_BodyCreationSettings_SetOverrideMassProperties")
+	purego.RegisterLibFunc(&jphBodyCreationSettingsGetOverrideMassProperties, handle, "JPH_BodyCreationSettings_GetOverrideMassProperties")
+	purego.RegisterLibFunc(&jphBodyCreationSettingsSetMassPropertiesOverride, handle, "JPH_BodyCreationSettings_SetMassPropertiesOverride")
+	purego.RegisterLibFunc(&jphBodyCreationSettingsGetMassPropertiesOverride, handle, "JPH_BodyCreationSettings_GetMassPropertiesOverride")
 
 	// --- BodyInterface ---
 	purego.RegisterLibFunc(&jphBodyInterfaceCreateAndAddBody, handle, "JPH_BodyInterface_CreateAndAddBody")
+	purego.RegisterLibFunc(&jphBodyInterfaceCreateBody, handle, "JPH_BodyInterface_CreateBody")
+	purego.RegisterLibFunc(&jphBodyInterfaceAddBody, handle, "JPH_BodyInterface_AddBody")
+	purego.RegisterLibFunc(&jphBodyInterfaceAddBodiesPrepare, handle, "JPH_BodyInterface_AddBodiesPrepare")
+	purego.RegisterLibFunc(&jphBodyInterfaceAddBodiesFinalize, handle, "JPH_BodyInterface_AddBodiesFinalize")
+	purego.RegisterLibFunc(&jphBodyInterfaceAddBodiesAbort, handle, "JPH_BodyInterface_AddBodiesAbort")
 	purego.RegisterLibFunc(&jphBodyInterfaceRemoveAndDestroyBody, handle, "JPH_BodyInterface_RemoveAndDestroyBody")
 	purego.RegisterLibFunc(&jphBodyInterfaceRemoveBody, handle, "JPH_BodyInterface_RemoveBody")
 	purego.RegisterLibFunc(&jphBodyInterfaceDestroyBody, handle, "JPH_BodyInterface_DestroyBody")
@@ -110,6 +266,8 @@ func registerSymbols(handle uintptr) {
 	purego.RegisterLibFunc(&jphBodyInterfaceGetPosition, handle, "JPH_BodyInterface_GetPosition")
 	purego.RegisterLibFunc(&jphBodyInterfaceSetRotation, handle, "JPH_BodyInterface_SetRotation")
 	purego.RegisterLibFunc(&jphBodyInterfaceGetRotation, handle, "JPH_BodyInterface_GetRotation")
+	purego.RegisterLibFunc(&jphBodyInterfaceGetPositionAndRotation, handle, "JPH_BodyInterface_GetPositionAndRotation")
+	purego.RegisterLibFunc(&jphBodyInterfaceGetLinearAndAngularVelocity, handle, "JPH_BodyInterface_GetLinearAndAngularVelocity")
 	purego.RegisterLibFunc(&jphBodyInterfaceActivateBody, handle, "JPH_BodyInterface_ActivateBody")
 	purego.RegisterLibFunc(&jphBodyInterfaceDeactivateBody, handle, "JPH_BodyInterface_DeactivateBody")
 	purego.RegisterLibFunc(&jphBodyInterfaceIsActive, handle, "JPH_BodyInterface_IsActive")
@@ -117,6 +275,22 @@ func registerSymbols(handle uintptr) {
 	purego.RegisterLibFunc(&jphBodyInterfaceAddImpulse, handle, "JPH_BodyInterface_AddImpulse")
 	purego.RegisterLibFunc(&jphBodyInterfaceAddImpulse2, handle, "JPH_BodyInterface_AddImpulse2")
 	purego.RegisterLibFunc(&jphBodyInterfaceAddAngularImpulse, handle, "JPH_BodyInterface_AddAngularImpulse")
+	purego.RegisterLibFunc(&jphBodyInterfaceAddForce2, handle, "JPH_BodyInterface_AddForce2")
+	purego.RegisterLibFunc(&jphBodyInterfaceAddTorque, handle, "JPH_BodyInterface_AddTorque")
+	purego.RegisterLibFunc(&jphBodyInterfaceSetAngularVelocity, handle, "JPH_BodyInterface_SetAngularVelocity")
+	purego.RegisterLibFunc(&jphBodyInterfaceGetAngularVelocity, handle, "JPH_BodyInterface_GetAngularVelocity")
+	purego.RegisterLibFunc(&jphBodyInterfaceSetLinearAndAngularVelocity, handle, "JPH_BodyInterface_SetLinearAndAngularVelocity")
+	purego.RegisterLibFunc(&jphBodyInterfaceAddLinearVelocity, handle, "JPH_BodyInterface_AddLinearVelocity")
+	purego.RegisterLibFunc(&jphBodyInterfaceGetPointVelocity, handle, "JPH_BodyInterface_GetPointVelocity")
+	purego.RegisterLibFunc(&jphBodyInterfaceSetPositionAndRotation, handle, "JPH_BodyInterface_SetPositionAndRotation")
+	purego.RegisterLibFunc(&jphBodyInterfaceGetObjectLayer, handle, "JPH_BodyInterface_GetObjectLayer")
+	purego.RegisterLibFunc(&jphBodyInterfaceSetObjectLayer, handle, "JPH_BodyInterface_SetObjectLayer")
+	purego.RegisterLibFunc(&jphBodyInterfaceSetUserData, handle, "JPH_BodyInterface_SetUserData")
+	purego.RegisterLibFunc(&jphBodyInterfaceGetUserData, handle, "JPH_BodyInterface_GetUserData")
+	purego.RegisterLibFunc(&jphBodyInterfaceGetShape, handle, "JPH_BodyInterface_GetShape")
+	purego.RegisterLibFunc(&jphBodyInterfaceSetShape, handle, "JPH_BodyInterface_SetShape")
+	purego.RegisterLibFunc(&jphBodyInterfaceMoveKinematic, handle, "JPH_BodyInterface_MoveKinematic")
+	purego.RegisterLibFunc(&jphBodyInterfaceSetPositionRotationAndVelocity, handle, "JPH_BodyInterface_SetPositionRotationAndVelocity")
 	purego.RegisterLibFunc(&jphBodyInterfaceSetFriction, handle, "JPH_BodyInterface_SetFriction")
 	purego.RegisterLibFunc(&jphBodyInterfaceGetFriction, handle, "JPH_BodyInterface_GetFriction")
 	purego.RegisterLibFunc(&jphBodyInterfaceSetRestitution, handle, "JPH_BodyInterface_SetRestitution")
@@ -125,4 +299,5 @@ func registerSymbols(handle uintptr) {
 	purego.RegisterLibFunc(&jphBodyInterfaceGetGravityFactor, handle, "JPH_BodyInterface_GetGravityFactor")
 	purego.RegisterLibFunc(&jphBodyInterfaceGetMotionType, handle, "JPH_BodyInterface_GetMotionType")
 	purego.RegisterLibFunc(&jphBodyInterfaceSetMotionType, handle, "JPH_BodyInterface_SetMotionType")
+	purego.RegisterLibFunc(&jphBodyInterfaceGetInverseInertia, handle, "JPH_BodyInterface_GetInverseInertia")
 }
@@ -0,0 +1,19 @@
+package jolt
+
+// MassProperties describes the mass and inertia tensor of a body.
+// The layout matches joltc's JPH_MassProperties so values can be passed
+// to the C API directly.
+//
+// Inertia is expressed around the center of mass, in the body's local space.
+// Only the upper-left 3x3 part is used; M44 should be 1.
+type MassProperties struct {
+	Mass    float32
+	Inertia Matrix4x4
+}
+
+// ScaleToMass scales the inertia tensor so that it matches the given mass,
+// then sets Mass. Use this to keep a shape's inertia distribution while
+// overriding its total mass.
+func (mp *MassProperties) ScaleToMass(mass float32) {
+	jphMassPropertiesScaleToMass(mp, mass)
+}
@@ -0,0 +1,191 @@
+package jolt
+
+import (
+	"sync"
+
+	"github.com/ebitengine/purego"
+)
+
+// NarrowPhaseQuery runs collision queries, such as ray casts, against the
+// bodies of a PhysicsSystem. Obtain it with PhysicsSystem.GetNarrowPhaseQuery.
+type NarrowPhaseQuery struct {
+	handle uintptr
+	system *PhysicsSystem
+}
+
+// GetNarrowPhaseQuery returns the NarrowPhaseQuery of the system. The
+// returned value is valid for the lifetime of this PhysicsSystem.
+//
+// Queries lock the bodies they test, and lock each hit body again to compute
+// its surface normal. They deadlock if run while the caller holds a body
+// lock, and must not be run from ContactListener, BodyActivationListener or
+// StepListener callbacks, which run while Jolt holds body locks.
+func (ps *PhysicsSystem) GetNarrowPhaseQuery() *NarrowPhaseQuery {
+	return &NarrowPhaseQuery{
+		handle: jphPhysicsSystemGetNarrowPhaseQuery(ps.handle),
+		system: ps,
+	}
+}
+
+// RayHit describes where a ray hit a body.
+type RayHit struct {
+	// BodyID is the body that was hit.
+	BodyID BodyID
+	// Fraction is the position of the hit along the ray, from 0 at the
+	// origin to 1 at origin + direction.
+	Fraction float32
+	// Point is the world-space hit point.
+	Point Vec3
+	// Normal is the world-space surface normal of the body at Point. It is
+	// zero if the body was removed before it could be locked to compute the
+	// normal.
+	Normal Vec3
+	// SubShapeID is the sub shape of the body that was hit.
+	SubShapeID SubShapeID
+}
+
+// CastRay casts a ray from origin along direction and returns the closest
+// hit. The length of direction is the length of the ray. It returns false if
+// no body passing filter was hit; filter may be nil.
+func (q *NarrowPhaseQuery) CastRay(origin, direction Vec3, filter *QueryFilter) (RayHit, bool) {
+	bpFilter, objFilter, bodyFilter := filter.native()
+	var result rayCastResult
+	if !jphNarrowPhaseQueryCastRay(q.handle, &origin, &direction, &result, bpFilter, objFilter, bodyFilter) {
+		return RayHit{}, false
+	}
+	return q.newRayHit(origin, direction, result), true
+}
+
+// newRayHit completes a joltc ray cast result with the hit point and the
+// surface normal of the body.
+func (q *NarrowPhaseQuery) newRayHit(origin, direction Vec3, result rayCastResult) RayHit {
+	hit := RayHit{
+		BodyID:     BodyID(result.BodyID),
+		Fraction:   result.Fraction,
+		SubShapeID: SubShapeID(result.SubShapeID2),
+		Point:      rayPoint(origin, direction, result.Fraction),
+	}
+	if body := q.system.LockBodyRead(hit.BodyID); body != nil {
+		jphBodyGetWorldSpaceSurfaceNormal(body.ptr(), result.SubShapeID2, &hit.Point, &hit.Normal)
+		body.Unlock()
+	}
+	return hit
+}
+
+// rayPoint returns the point at fraction along the ray from origin along
+// direction.
+func rayPoint(origin, direction Vec3, fraction float32) Vec3 {
+	return Vec3{
+		X: origin.X + direction.X*fraction,
+		Y: origin.Y + direction.Y*fraction,
+		Z: origin.Z + direction.Z*fraction,
+	}
+}
+
+// RayCast is a ray starting at Origin. The length of Direction is the length
+// of the ray.
+type RayCast struct {
+	Origin    Vec3
+	Direction Vec3
+}
+
+// RayCastSettings controls how CastRayAll treats back faces and convex
+// shapes. Use DefaultRayCastSettings for Jolt's defaults.
+type RayCastSettings struct {
+	// BackFaceModeTriangles controls hits on the back of mesh triangles.
+	BackFaceModeTriangles BackFaceMode
+	// BackFaceModeConvex controls hits on the inside of convex shapes. It
+	// only applies when TreatConvexAsSolid is false.
+	BackFaceModeConvex BackFaceMode
+	// TreatConvexAsSolid reports a hit at fraction 0 for rays starting
+	// inside a convex shape. When false, such rays hit the shape from the
+	// inside, if back faces are collided with.
+	TreatConvexAsSolid bool
+}
+
+// DefaultRayCastSettings returns settings that ignore back faces and treat
+// convex shapes as solid, as CastRay does.
+func DefaultRayCastSettings() RayCastSettings {
+	return RayCastSettings{TreatConvexAsSolid: true}
+}
+
+// RayCastCollector receives the hits of CastRayAll.
+type RayCastCollector interface {
+	// CollectorType selects which hits are collected. With
+	// CollisionCollectorClosestHit and CollisionCollectorAnyHit the query
+	// stops testing bodies early and AddHit is called at most once.
+	CollectorType() CollisionCollectorType
+	// AddHit is called for each collected hit, on the goroutine running the
+	// query, after the query has finished.
+	AddHit(hit RayHit)
+}
+
+// RayHitCollector is a RayCastCollector that appends hits to Hits. Give Hits
+// spare capacity to collect without allocating, and reset it to Hits[:0]
+// before reusing the collector.
+type RayHitCollector struct {
+	Type CollisionCollectorType
+	Hits []RayHit
+}
+
+// CollectorType returns c.Type.
+func (c *RayHitCollector) CollectorType() CollisionCollectorType {
+	return c.Type
+}
+
+// AddHit appends hit to c.Hits.
+func (c *RayHitCollector) AddHit(hit RayHit) {
+	c.Hits = append(c.Hits, hit)
+}
+
+// CastRayAll casts ray and passes the hits selected by the collector's type
+// to collector.AddHit. settings may be nil to use DefaultRayCastSettings, and
+// filter may be nil to consider all bodies. It returns whether any body was
+// hit, and false without casting if collector is nil.
+func (q *NarrowPhaseQuery) CastRayAll(ray RayCast, settings *RayCastSettings, collector RayCastCollector, filter *QueryFilter) bool {
+	if collector == nil {
+		return false
+	}
+	cs := settings.toC()
+	ref := handles.add(&rayCastContext{query: q, ray: ray, collector: collector})
+	defer handles.remove(ref)
+
+	bpFilter, objFilter, bodyFilter := filter.native()
+	return jphNarrowPhaseQueryCastRay3(q.handle, &ray.Origin, &ray.Direction, &cs,
+		int32(collector.CollectorType()), castRayResultCallback(), uintptr(ref),
+		bpFilter, objFilter, bodyFilter)
+}
+
+// toC converts s to its C layout, using DefaultRayCastSettings if s is nil.
+func (s *RayCastSettings) toC() rayCastSettings {
+	if s == nil {
+		defaults := DefaultRayCastSettings()
+		s = &defaults
+	}
+	return rayCastSettings{
+		BackFaceModeTriangles: int32(s.BackFaceModeTriangles),
+		BackFaceModeConvex:    int32(s.BackFaceModeConvex),
+		TreatConvexAsSolid:    s.TreatConvexAsSolid,
+	}
+}
+
+// rayCastContext is the userData of a CastRayAll result callback.
+type rayCastContext struct {
+	query     *NarrowPhaseQuery
+	ray       RayCast
+	collector RayCastCollector
+}
+
+// castRayResultCallback returns the C callback shared by all CastRayAll
+// calls, creating it on first use.
+var castRayResultCallback = sync.OnceValue(func() uintptr {
+	return purego.NewCallback(onCastRayResult)
+})
+
+func onCastRayResult(userData uintptr, result *rayCastResult) uintptr {
+	v, _ := handles.get(uint64(userData))
+	if ctx, ok := v.(*rayCastContext); ok {
+		ctx.collector.AddHit(ctx.query.newRayHit(ctx.ray.Origin, ctx.ray.Direction, *result))
+	}
+	return 0
+}
@@ -0,0 +1,96 @@
+package jolt
+
+// PhysicsSettings tunes the solver and sleeping behavior of a PhysicsSystem.
+// Read the current values with PhysicsSystem.GetPhysicsSettings, change the
+// fields of interest, and apply them with PhysicsSystem.SetPhysicsSettings.
+//
+// The fields are in the order of the C struct JPH_PhysicsSettings, which is
+// passed to joltc directly.
+type PhysicsSettings struct {
+	// MaxInFlightBodyPairs is the size of the body pair queue per job.
+	MaxInFlightBodyPairs int32
+	// StepListenersBatchSize is the number of step listeners called per batch.
+	StepListenersBatchSize int32
+	// StepListenerBatchesPerJob is the number of step listener batches per job.
+	StepListenerBatchesPerJob int32
+
+	// Baumgarte is the fraction of position error corrected per step (0..1).
+	Baumgarte float32
+	// SpeculativeContactDistance is the distance (m) at which contacts are
+	// created before bodies touch, to avoid tunneling and jitter.
+	SpeculativeContactDistance float32
+	// PenetrationSlop is the penetration (m) allowed before the position
+	// solver pushes bodies apart.
+	PenetrationSlop float32
+	// LinearCastThreshold is the fraction of its inner radius a
+	// MotionQualityLinearCast body must move per step to use a linear cast.
+	LinearCastThreshold float32
+	// LinearCastMaxPenetration is the fraction of its inner radius a
+	// MotionQualityLinearCast body may penetrate another body.
+	LinearCastMaxPenetration float32
+	// ManifoldTolerance is the distance (m) within which contact points are
+	// merged into one manifold.
+	ManifoldTolerance float32
+	// MaxPenetrationDistance is the maximum distance (m) used to resolve
+	// penetration in a single step.
+	MaxPenetrationDistance float32
+	// BodyPairCacheMaxDeltaPositionSq is the squared relative movement (m²)
+	// below which cached contacts between two bodies are reused.
+	BodyPairCacheMaxDeltaPositionSq float32
+	// BodyPairCacheCosMaxDeltaRotationDiv2 is the cosine of half the relative
+	// rotation below which cached contacts between two bodies are reused.
+	BodyPairCacheCosMaxDeltaRotationDiv2 float32
+	// ContactNormalCosMaxDeltaRotation is the cosine of the angle between
+	// contact normals below which warm-start impulses are reused.
+	ContactNormalCosMaxDeltaRotation float32
+	// ContactPointPreserveLambdaMaxDistSq is the squared distance (m²) a
+	// contact point may move and still reuse its warm-start impulse.
+	ContactPointPreserveLambdaMaxDistSq float32
+
+	// NumVelocitySteps is the number of velocity solver iterations.
+	NumVelocitySteps uint32
+	// NumPositionSteps is the number of position solver iterations.
+	NumPositionSteps uint32
+
+	// MinVelocityForRestitution is the approach speed (m/s) below which
+	// restitution is ignored.
+	MinVelocityForRestitution float32
+	// TimeBeforeSleep is how long (s) a body must be nearly still before it
+	// goes to sleep.
+	TimeBeforeSleep float32
+	// PointVelocitySleepThreshold is the speed (m/s) below which every point
+	// of a body counts as still for sleeping.
+	PointVelocitySleepThreshold float32
+
+	// DeterministicSimulation makes the simulation produce identical results
+	// across runs, at some performance cost.
+	DeterministicSimulation bool
+	// ConstraintWarmStart starts the solver from last step's impulses.
+	ConstraintWarmStart bool
+	// UseBodyPairContactCache reuses contacts between steps for bodies that
+	// barely moved.
+	UseBodyPairContactCache bool
+	// UseManifoldReduction merges contact points with similar normals.
+	UseManifoldReduction bool
+	// UseLargeIslandSplitter splits large islands so they can be solved in
+	// parallel.
+	UseLargeIslandSplitter bool
+	// AllowSleeping allows bodies to go to sleep at all.
+	AllowSleeping bool
+	// CheckActiveEdges filters collisions with inactive mesh edges.
+	CheckActiveEdges bool
+}
+
+// GetPhysicsSettings returns the current simulation settings.
+func (ps *PhysicsSystem) GetPhysicsSettings() PhysicsSettings {
+	var settings PhysicsSettings
+	jphPhysicsSystemGetPhysicsSettings(ps.handle, (*physicsSettings)(&settings))
+	return settings
+}
+
+// SetPhysicsSettings replaces the simulation settings. Start from
+// GetPhysicsSettings so that fields you do not change keep their values.
+// It must not be called during Update.
+func (ps *PhysicsSystem) SetPhysicsSettings(settings PhysicsSettings) {
+	jphPhysicsSystemSetPhysicsSettings(ps.handle, (*physicsSettings)(&settings))
+}
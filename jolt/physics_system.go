@@ -9,11 +9,20 @@ package jolt
 type PhysicsSystem struct {
 	handle uintptr
 
+	// lockInterface is the locking body lock interface of the system.
+	lockInterface uintptr
+
 	// Keep references to prevent garbage collection of resources that the
 	// C PhysicsSystem holds pointers to.
 	bpLayerInterface *BroadPhaseLayerInterface
 	objLayerFilter   *ObjectLayerPairFilter
 	objVsBPFilter    *ObjectVsBroadPhaseLayerFilter
+
+	// Listeners installed on the system, destroyed in Close.
+	contactListener    nativeListener
+	activationListener nativeListener
+	stepListener       nativeListener
+	stepListeners      *stepListeners
 }
 
 // PhysicsSystemConfig holds configuration for creating a PhysicsSystem.
@@ -52,17 +61,26 @@ func NewPhysicsSystem(cfg *PhysicsSystemConfig) *PhysicsSystem {
 	h := jphPhysicsSystemCreate(settings)
 	return &PhysicsSystem{
 		handle:           h,
+		lockInterface:    jphPhysicsSystemGetBodyLockInterface(h),
 		bpLayerInterface: cfg.BroadPhaseLayer,
 		objLayerFilter:   cfg.ObjectLayerPairFilter,
 		objVsBPFilter:    cfg.ObjectVsBPLayerFilter,
 	}
 }
 
-// Close destroys the physics system and releases all C resources.
+// Close destroys the physics system and releases all C resources, including
+// the Go values attached to its remaining bodies with SetUserData.
 func (ps *PhysicsSystem) Close() {
 	if ps.handle != 0 {
+		bi := ps.GetBodyInterface()
+		for _, id := range ps.AppendBodies(nil) {
+			bi.releaseUserData(id)
+		}
 		jphPhysicsSystemDestroy(ps.handle)
 		ps.handle = 0
+		ps.contactListener.release(jphContactListenerDestroy, contactListeners.remove)
+		ps.activationListener.release(jphBodyActivationListenerDestroy, bodyActivationListeners.remove)
+		ps.stepListener.release(jphPhysicsStepListenerDestroy, stepListenerSets.remove)
 	}
 }
 
@@ -85,7 +103,10 @@ func (ps *PhysicsSystem) Update(deltaTime float32, collisionSteps int, jobSystem
 // the lifetime of this PhysicsSystem.
 func (ps *PhysicsSystem) GetBodyInterface() *BodyInterface {
 	h := jphPhysicsSystemGetBodyInterface(ps.handle)
-	return &BodyInterface{handle: h}
+	return &BodyInterface{
+		handle:        h,
+		lockInterface: ps.lockInterface,
+	}
 }
 
 // SetGravity sets the global gravity vector.
@@ -105,9 +126,9 @@ func (ps *PhysicsSystem) GetNumBodies() uint32 {
 	return jphPhysicsSystemGetNumBodies(ps.handle)
 }
 
-// GetNumActiveBodies returns the number of active rigid bodies.
-func (ps *PhysicsSystem) GetNumActiveBodies() uint32 {
-	return jphPhysicsSystemGetNumActiveBodies(ps.handle, 0) // 0 = JPH_BodyType_Rigid
+// GetNumActiveBodies returns the number of active bodies of the given type.
+func (ps *PhysicsSystem) GetNumActiveBodies(bodyType BodyType) uint32 {
+	return jphPhysicsSystemGetNumActiveBodies(ps.handle, int32(bodyType))
 }
 
 // GetMaxBodies returns the maximum number of bodies supported.
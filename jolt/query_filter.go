@@ -0,0 +1,152 @@
+package jolt
+
+import (
+	"sync"
+
+	"github.com/ebitengine/purego"
+)
+
+// QueryFilterFuncs selects which bodies a collision query considers. Nil
+// fields accept everything.
+//
+// The functions are called on the goroutine running the query, while Jolt
+// holds internal locks: they must not lock bodies or call BodyInterface
+// methods.
+type QueryFilterFuncs struct {
+	// BroadPhaseLayer reports whether bodies in a broad-phase layer are
+	// considered.
+	BroadPhaseLayer func(layer BroadPhaseLayer) bool
+	// ObjectLayer reports whether bodies in an object layer are considered.
+	ObjectLayer func(layer ObjectLayer) bool
+	// Body reports whether a body is considered, for example to ignore the
+	// body casting the ray.
+	Body func(bodyID BodyID) bool
+}
+
+// QueryFilter holds the C filter objects of a QueryFilterFuncs. Create it
+// once with NewQueryFilter and reuse it for any number of queries, which then
+// neither allocate nor lock to run the filter. A nil *QueryFilter accepts all
+// bodies.
+type QueryFilter struct {
+	broadPhaseLayer     uintptr // C filter objects, 0 for nil functions
+	objectLayer         uintptr
+	body                uintptr
+	broadPhaseLayerSlot uint32 // queryFilter*Funcs slots of the functions
+	objectLayerSlot     uint32
+	bodySlot            uint32
+}
+
+// Go functions of query filters, looked up without locking by the
+// callbacks.
+var (
+	queryFilterBroadPhaseLayerFuncs slotTable[func(BroadPhaseLayer) bool]
+	queryFilterObjectLayerFuncs     slotTable[func(ObjectLayer) bool]
+	queryFilterBodyFuncs            slotTable[func(BodyID) bool]
+)
+
+// NewQueryFilter creates C filter objects for the non-nil functions of fns.
+// Call Destroy when the filter is no longer used by any query.
+func NewQueryFilter(fns QueryFilterFuncs) *QueryFilter {
+	f := &QueryFilter{}
+	if fns.BroadPhaseLayer == nil && fns.ObjectLayer == nil && fns.Body == nil {
+		return f
+	}
+	registerQueryFilterProcs()
+	if fns.BroadPhaseLayer != nil {
+		f.broadPhaseLayerSlot = queryFilterBroadPhaseLayerFuncs.add(fns.BroadPhaseLayer)
+		f.broadPhaseLayer = jphBroadPhaseLayerFilterCreate(uintptr(f.broadPhaseLayerSlot))
+	}
+	if fns.ObjectLayer != nil {
+		f.objectLayerSlot = queryFilterObjectLayerFuncs.add(fns.ObjectLayer)
+		f.objectLayer = jphObjectLayerFilterCreate(uintptr(f.objectLayerSlot))
+	}
+	if fns.Body != nil {
+		f.bodySlot = queryFilterBodyFuncs.add(fns.Body)
+		f.body = jphBodyFilterCreate(uintptr(f.bodySlot))
+	}
+	return f
+}
+
+// Destroy frees the C filter objects and releases the Go functions. The
+// filter must not be used afterwards; calling Destroy again is a no-op.
+func (f *QueryFilter) Destroy() {
+	if f.broadPhaseLayer != 0 {
+		jphBroadPhaseLayerFilterDestroy(f.broadPhaseLayer)
+	}
+	if f.objectLayer != 0 {
+		jphObjectLayerFilterDestroy(f.objectLayer)
+	}
+	if f.body != 0 {
+		jphBodyFilterDestroy(f.body)
+	}
+	queryFilterBroadPhaseLayerFuncs.remove(f.broadPhaseLayerSlot)
+	queryFilterObjectLayerFuncs.remove(f.objectLayerSlot)
+	queryFilterBodyFuncs.remove(f.bodySlot)
+	*f = QueryFilter{}
+}
+
+// native returns the C filter objects to pass to a query, all 0 for a nil
+// filter.
+func (f *QueryFilter) native() (broadPhaseLayer, objectLayer, body uintptr) {
+	if f == nil {
+		return 0, 0, 0
+	}
+	return f.broadPhaseLayer, f.objectLayer, f.body
+}
+
+// broadPhaseLayerFilterProcs mirrors the C struct
+// JPH_BroadPhaseLayerFilter_Procs.
+type broadPhaseLayerFilterProcs struct {
+	ShouldCollide uintptr
+}
+
+// objectLayerFilterProcs mirrors the C struct JPH_ObjectLayerFilter_Procs.
+type objectLayerFilterProcs struct {
+	ShouldCollide uintptr
+}
+
+// bodyFilterProcs mirrors the C struct JPH_BodyFilter_Procs.
+type bodyFilterProcs struct {
+	ShouldCollide       uintptr
+	ShouldCollideLocked uintptr
+}
+
+var queryFilterProcsOnce sync.Once
+
+// registerQueryFilterProcs installs the Go callbacks shared by all query
+// filters, as registerContactListenerProcs does for contacts.
+func registerQueryFilterProcs() {
+	queryFilterProcsOnce.Do(func() {
+		jphBroadPhaseLayerFilterSetProcs(&broadPhaseLayerFilterProcs{
+			ShouldCollide: purego.NewCallback(broadPhaseLayerFilterShouldCollide),
+		})
+		jphObjectLayerFilterSetProcs(&objectLayerFilterProcs{
+			ShouldCollide: purego.NewCallback(objectLayerFilterShouldCollide),
+		})
+		jphBodyFilterSetProcs(&bodyFilterProcs{
+			ShouldCollide:       purego.NewCallback(bodyFilterShouldCollide),
+			ShouldCollideLocked: purego.NewCallback(bodyFilterShouldCollideLocked),
+		})
+	})
+}
+
+func broadPhaseLayerFilterShouldCollide(userData uintptr, layer uint8) uintptr {
+	fn := queryFilterBroadPhaseLayerFuncs.get(userData)
+	return boolResult(fn == nil || fn(BroadPhaseLayer(layer)))
+}
+
+func objectLayerFilterShouldCollide(userData uintptr, layer uint32) uintptr {
+	fn := queryFilterObjectLayerFuncs.get(userData)
+	return boolResult(fn == nil || fn(ObjectLayer(layer)))
+}
+
+func bodyFilterShouldCollide(userData uintptr, bodyID uint32) uintptr {
+	fn := queryFilterBodyFuncs.get(userData)
+	return boolResult(fn == nil || fn(BodyID(bodyID)))
+}
+
+// bodyFilterShouldCollideLocked accepts every body that passed
+// bodyFilterShouldCollide.
+func bodyFilterShouldCollideLocked(userData, body uintptr) uintptr {
+	return 1
+}
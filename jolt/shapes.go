@@ -4,12 +4,17 @@ package jolt
 // Shapes are reference-counted in joltc; once assigned to a body the body
 // holds a reference. Call Destroy() only on shapes that have NOT been assigned
 // to any body, or after all referencing bodies have been removed.
+//
+// Shapes returned by BodyInterface.GetShape and Body.GetShape hold their own
+// reference, so they stay valid after the body changes shape or is destroyed.
+// Call Destroy on them when done to release that reference.
 type Shape struct {
 	handle uintptr
 }
 
-// Destroy releases the underlying C shape resource.
-// Only call this on shapes that are no longer referenced by any body.
+// Destroy releases the Go reference to the underlying C shape. Only call this
+// on shapes that are no longer referenced by any body, or on shapes returned
+// by GetShape. Calling Destroy more than once is a no-op.
 func (s *Shape) Destroy() {
 	if s.handle != 0 {
 		jphShapeDestroy(s.handle)
@@ -17,6 +22,14 @@ func (s *Shape) Destroy() {
 	}
 }
 
+// GetMassProperties returns the mass properties the shape would give a body,
+// based on its volume and density.
+func (s *Shape) GetMassProperties() MassProperties {
+	var mp MassProperties
+	jphShapeGetMassProperties(s.handle, &mp)
+	return mp
+}
+
 // NewBoxShape creates a box collision shape with the given half extents.
 // convexRadius adds rounding to edges for smoother collision (use 0.05 as default).
 func NewBoxShape(halfExtent Vec3, convexRadius float32) *Shape {
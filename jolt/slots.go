@@ -0,0 +1,64 @@
+package jolt
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// slotTable stores Go values for C objects whose callbacks run on Jolt's
+// worker threads for every pair test or contact, where taking the handleTable
+// lock would be too costly. Lookups are a lock-free atomic load; adding and removing
+// copy the table, which is fine because they are rare.
+//
+// Slots are numbered from 1 so that 0 can mean "no slot". Removed slots are
+// reused by later adds.
+type slotTable[T any] struct {
+	mu    sync.Mutex // serializes add and remove
+	slots atomic.Pointer[[]T]
+	free  []uint32
+}
+
+// add stores v and returns its slot.
+func (t *slotTable[T]) add(v T) uint32 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var slots []T
+	if p := t.slots.Load(); p != nil {
+		slots = append(slots, *p...)
+	}
+	var slot uint32
+	if n := len(t.free); n > 0 {
+		slot = t.free[n-1]
+		t.free = t.free[:n-1]
+		slots[slot-1] = v
+	} else {
+		slots = append(slots, v)
+		slot = uint32(len(slots))
+	}
+	t.slots.Store(&slots)
+	return slot
+}
+
+// get returns the value in slot, or the zero value if the slot is empty.
+func (t *slotTable[T]) get(slot uintptr) T {
+	var zero T
+	p := t.slots.Load()
+	if p == nil || slot == 0 || slot > uintptr(len(*p)) {
+		return zero
+	}
+	return (*p)[slot-1]
+}
+
+// remove empties slot. Removing slot 0 is a no-op.
+func (t *slotTable[T]) remove(slot uint32) {
+	if slot == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	slots := append([]T(nil), *t.slots.Load()...)
+	var zero T
+	slots[slot-1] = zero
+	t.slots.Store(&slots)
+	t.free = append(t.free, slot)
+}
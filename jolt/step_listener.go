@@ -0,0 +1,138 @@
+package jolt
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ebitengine/purego"
+)
+
+// StepContext is passed to step listeners once per collision step.
+type StepContext struct {
+	// DeltaTime is the duration (s) of this collision step: the deltaTime
+	// passed to Update divided by collisionSteps.
+	DeltaTime float32
+	// IsFirstStep is true for the first collision step of an Update.
+	IsFirstStep bool
+	// IsLastStep is true for the last collision step of an Update.
+	IsLastStep bool
+	// BodyInterface is a body interface that does not lock bodies. Jolt
+	// does not modify bodies while step listeners run, so it may be used
+	// freely from the listener, but it must not be kept after it returns.
+	BodyInterface *BodyInterface
+}
+
+// StepListener is a function registered with PhysicsSystem.AddStepListener.
+// It is used to remove the function again.
+type StepListener struct {
+	fn func(ctx StepContext)
+}
+
+// stepListeners is the ordered set of step listeners of a PhysicsSystem. It
+// is dispatched to by a single native step listener.
+type stepListeners struct {
+	mu            sync.Mutex // serializes add and remove
+	list          atomic.Pointer[[]*StepListener]
+	bodyInterface *BodyInterface
+}
+
+// AddStepListener registers fn to be called at the start of every collision
+// step of Update, before collision detection, for example to apply wind,
+// thrust or custom gravity with the step's DeltaTime.
+//
+// Listeners run one after another in the order they were added, on one of
+// Jolt's worker threads. Adding or removing listeners, including from inside
+// a listener, takes effect from the next collision step.
+func (ps *PhysicsSystem) AddStepListener(fn func(ctx StepContext)) *StepListener {
+	if ps.stepListeners == nil {
+		registerStepListenerProcs()
+		ps.stepListeners = &stepListeners{
+			bodyInterface: &BodyInterface{
+				handle:        jphPhysicsSystemGetBodyInterfaceNoLock(ps.handle),
+				lockInterface: jphPhysicsSystemGetBodyLockInterfaceNoLock(ps.handle),
+			},
+		}
+		ps.stepListener.slot = stepListenerSets.add(ps.stepListeners)
+		ps.stepListener.handle = jphPhysicsStepListenerCreate(uintptr(ps.stepListener.slot))
+		jphPhysicsSystemAddStepListener(ps.handle, ps.stepListener.handle)
+	}
+
+	l := &StepListener{fn: fn}
+	s := ps.stepListeners
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var list []*StepListener
+	if p := s.list.Load(); p != nil {
+		list = *p
+	}
+	list = append(slices.Clip(list), l)
+	s.list.Store(&list)
+	return l
+}
+
+// RemoveStepListener unregisters a listener returned by AddStepListener.
+// Removing a listener that is not registered is a no-op.
+func (ps *PhysicsSystem) RemoveStepListener(l *StepListener) {
+	s := ps.stepListeners
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.list.Load()
+	if p == nil {
+		return
+	}
+	i := slices.Index(*p, l)
+	if i < 0 {
+		return
+	}
+	list := slices.Delete(slices.Clone(*p), i, i+1)
+	s.list.Store(&list)
+}
+
+// run calls the registered listeners in order.
+func (s *stepListeners) run(ctx StepContext) {
+	p := s.list.Load()
+	if p == nil {
+		return
+	}
+	for _, l := range *p {
+		l.fn(ctx)
+	}
+}
+
+// physicsStepListenerProcs mirrors the C struct JPH_PhysicsStepListener_Procs.
+type physicsStepListenerProcs struct {
+	OnStep uintptr
+}
+
+var stepListenerProcsOnce sync.Once
+
+// registerStepListenerProcs installs the Go callback shared by all step
+// listeners, as registerContactListenerProcs does for contacts.
+func registerStepListenerProcs() {
+	stepListenerProcsOnce.Do(func() {
+		procs := physicsStepListenerProcs{
+			OnStep: purego.NewCallback(onStep),
+		}
+		jphPhysicsStepListenerSetProcs(&procs)
+	})
+}
+
+// stepListenerSets holds the step listeners of each PhysicsSystem that has
+// any.
+var stepListenerSets slotTable[*stepListeners]
+
+func onStep(userData uintptr, context *physicsStepListenerContext) uintptr {
+	if s := stepListenerSets.get(userData); s != nil {
+		s.run(StepContext{
+			DeltaTime:     context.DeltaTime,
+			IsFirstStep:   context.IsFirstStep != 0,
+			IsLastStep:    context.IsLastStep != 0,
+			BodyInterface: s.bodyInterface,
+		})
+	}
+	return 0
+}
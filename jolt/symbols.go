@@ -25,14 +25,24 @@ var jphJobSystemDestroy func(jobSystem uintptr)
 // --- BroadPhaseLayerInterface ---
 var jphBroadPhaseLayerInterfaceTableCreate func(numObjectLayers, numBroadPhaseLayers uint32) uintptr
 var jphBroadPhaseLayerInterfaceTableMapObjectToBroadPhaseLayer func(bpInterface uintptr, objectLayer uint32, broadPhaseLayer uint8)
+var jphBroadPhaseLayerInterfaceMaskCreate func(numBroadPhaseLayers uint32) uintptr
+var jphBroadPhaseLayerInterfaceMaskConfigureLayer func(bpInterface uintptr, broadPhaseLayer uint8, groupsToInclude, groupsToExclude uint32)
+var jphBroadPhaseLayerInterfaceSetProcs func(procs *broadPhaseLayerInterfaceProcs)
+var jphBroadPhaseLayerInterfaceCreate func(userData uintptr) uintptr
 
 // --- ObjectLayerPairFilter ---
 var jphObjectLayerPairFilterTableCreate func(numObjectLayers uint32) uintptr
 var jphObjectLayerPairFilterTableEnableCollision func(filter uintptr, layer1, layer2 uint32)
 var jphObjectLayerPairFilterTableDisableCollision func(filter uintptr, layer1, layer2 uint32)
+var jphObjectLayerPairFilterMaskCreate func() uintptr
+var jphObjectLayerPairFilterSetProcs func(procs *objectLayerPairFilterProcs)
+var jphObjectLayerPairFilterCreate func(userData uintptr) uintptr
 
 // --- ObjectVsBroadPhaseLayerFilter ---
 var jphObjectVsBroadPhaseLayerFilterTableCreate func(bpInterface uintptr, numBroadPhaseLayers uint32, objectFilter uintptr, numObjectLayers uint32) uintptr
+var jphObjectVsBroadPhaseLayerFilterMaskCreate func(bpInterface uintptr) uintptr
+var jphObjectVsBroadPhaseLayerFilterSetProcs func(procs *objectVsBroadPhaseLayerFilterProcs)
+var jphObjectVsBroadPhaseLayerFilterCreate func(userData uintptr) uintptr
 
 // --- PhysicsSystem ---
 
@@ -48,6 +58,38 @@ type physicsSystemSettings struct {
 	ObjectVsBroadPhaseLayerFilter uintptr
 }
 
+// physicsSettings mirrors the C struct JPH_PhysicsSettings. PhysicsSettings
+// has the same fields, so pointers to it are converted to *physicsSettings
+// without copying; the conversion stops compiling if the two drift apart.
+type physicsSettings struct {
+	MaxInFlightBodyPairs                 int32
+	StepListenersBatchSize               int32
+	StepListenerBatchesPerJob            int32
+	Baumgarte                            float32
+	SpeculativeContactDistance           float32
+	PenetrationSlop                      float32
+	LinearCastThreshold                  float32
+	LinearCastMaxPenetration             float32
+	ManifoldTolerance                    float32
+	MaxPenetrationDistance               float32
+	BodyPairCacheMaxDeltaPositionSq      float32
+	BodyPairCacheCosMaxDeltaRotationDiv2 float32
+	ContactNormalCosMaxDeltaRotation     float32
+	ContactPointPreserveLambdaMaxDistSq  float32
+	NumVelocitySteps                     uint32
+	NumPositionSteps                     uint32
+	MinVelocityForRestitution            float32
+	TimeBeforeSleep                      float32
+	PointVelocitySleepThreshold          float32
+	DeterministicSimulation              bool
+	ConstraintWarmStart                  bool
+	UseBodyPairContactCache              bool
+	UseManifoldReduction                 bool
+	UseLargeIslandSplitter               bool
+	AllowSleeping                        bool
+	CheckActiveEdges                     bool
+}
+
 var jphPhysicsSystemCreate func(settings *physicsSystemSettings) uintptr
 var jphPhysicsSystemDestroy func(system uintptr)
 var jphPhysicsSystemOptimizeBroadPhase func(system uintptr)
@@ -58,6 +100,200 @@ var jphPhysicsSystemGetGravity func(system uintptr, result *Vec3)
 var jphPhysicsSystemGetNumBodies func(system uintptr) uint32
 var jphPhysicsSystemGetNumActiveBodies func(system uintptr, bodyType int32) uint32
 var jphPhysicsSystemGetMaxBodies func(system uintptr) uint32
+var jphPhysicsSystemGetPhysicsSettings func(system uintptr, result *physicsSettings)
+var jphPhysicsSystemSetPhysicsSettings func(system uintptr, settings *physicsSettings)
+var jphPhysicsSystemGetBodies func(system uintptr, ids *BodyID, count uint32)
+var jphPhysicsSystemGetBodyLockInterface func(system uintptr) uintptr
+
+// --- BodyLockInterface ---
+
+// bodyLockRead mirrors the C struct JPH_BodyLockRead.
+type bodyLockRead struct {
+	LockInterface uintptr
+	Mutex         uintptr
+	Body          uintptr
+}
+
+var jphBodyLockInterfaceLockRead func(lockInterface uintptr, bodyID uint32, outLock *bodyLockRead)
+var jphBodyLockInterfaceUnlockRead func(lockInterface uintptr, ioLock *bodyLockRead)
+
+// bodyLockWrite mirrors the C struct JPH_BodyLockWrite.
+type bodyLockWrite struct {
+	LockInterface uintptr
+	Mutex         uintptr
+	Body          uintptr
+}
+
+var jphBodyLockInterfaceLockWrite func(lockInterface uintptr, bodyID uint32, outLock *bodyLockWrite)
+var jphBodyLockInterfaceUnlockWrite func(lockInterface uintptr, ioLock *bodyLockWrite)
+
+// --- ContactListener ---
+
+// collideShapeResult mirrors the leading fields of the C struct
+// JPH_CollideShapeResult; the trailing face arrays are not read.
+type collideShapeResult struct {
+	ContactPointOn1  Vec3
+	ContactPointOn2  Vec3
+	PenetrationAxis  Vec3
+	PenetrationDepth float32
+	SubShapeID1      uint32
+	SubShapeID2      uint32
+	BodyID2          uint32
+}
+
+var jphContactListenerSetProcs func(procs *contactListenerProcs)
+var jphContactListenerCreate func(userData uintptr) uintptr
+var jphContactListenerDestroy func(listener uintptr)
+var jphPhysicsSystemSetContactListener func(system, listener uintptr)
+
+// --- BodyActivationListener ---
+var jphBodyActivationListenerSetProcs func(procs *bodyActivationListenerProcs)
+var jphBodyActivationListenerCreate func(userData uintptr) uintptr
+var jphBodyActivationListenerDestroy func(listener uintptr)
+var jphPhysicsSystemSetBodyActivationListener func(system, listener uintptr)
+
+// --- PhysicsStepListener ---
+
+// physicsStepListenerContext mirrors the C struct
+// JPH_PhysicsStepListenerContext.
+type physicsStepListenerContext struct {
+	DeltaTime     float32
+	IsFirstStep   uint32
+	IsLastStep    uint32
+	PhysicsSystem uintptr
+}
+
+var jphPhysicsStepListenerSetProcs func(procs *physicsStepListenerProcs)
+var jphPhysicsStepListenerCreate func(userData uintptr) uintptr
+var jphPhysicsStepListenerDestroy func(listener uintptr)
+var jphPhysicsSystemAddStepListener func(system, listener uintptr)
+var jphPhysicsSystemGetBodyInterfaceNoLock func(system uintptr) uintptr
+var jphPhysicsSystemGetBodyLockInterfaceNoLock func(system uintptr) uintptr
+
+// --- Query filters ---
+var jphBroadPhaseLayerFilterSetProcs func(procs *broadPhaseLayerFilterProcs)
+var jphBroadPhaseLayerFilterCreate func(userData uintptr) uintptr
+var jphBroadPhaseLayerFilterDestroy func(filter uintptr)
+var jphObjectLayerFilterSetProcs func(procs *objectLayerFilterProcs)
+var jphObjectLayerFilterCreate func(userData uintptr) uintptr
+var jphObjectLayerFilterDestroy func(filter uintptr)
+var jphBodyFilterSetProcs func(procs *bodyFilterProcs)
+var jphBodyFilterCreate func(userData uintptr) uintptr
+var jphBodyFilterDestroy func(filter uintptr)
+
+// --- NarrowPhaseQuery ---
+
+// rayCastResult mirrors the C struct JPH_RayCastResult.
+type rayCastResult struct {
+	BodyID      uint32
+	Fraction    float32
+	SubShapeID2 uint32
+}
+
+// rayCastSettings mirrors the C struct JPH_RayCastSettings.
+type rayCastSettings struct {
+	BackFaceModeTriangles int32
+	BackFaceModeConvex    int32
+	TreatConvexAsSolid    bool
+}
+
+var jphPhysicsSystemGetNarrowPhaseQuery func(system uintptr) uintptr
+var jphNarrowPhaseQueryCastRay func(query uintptr, origin, direction *Vec3, hit *rayCastResult, broadPhaseLayerFilter, objectLayerFilter, bodyFilter uintptr) bool
+var jphNarrowPhaseQueryCastRay3 func(query uintptr, origin, direction *Vec3, settings *rayCastSettings, collectorType int32, callback, userData uintptr, broadPhaseLayerFilter, objectLayerFilter, bodyFilter uintptr) bool
+
+// --- ContactManifold ---
+var jphContactManifoldGetWorldSpaceNormal func(manifold uintptr, result *Vec3)
+var jphContactManifoldGetPenetrationDepth func(manifold uintptr) float32
+var jphContactManifoldGetSubShapeID1 func(manifold uintptr) uint32
+var jphContactManifoldGetSubShapeID2 func(manifold uintptr) uint32
+var jphContactManifoldGetPointCount func(manifold uintptr) uint32
+var jphContactManifoldGetWorldSpaceContactPointOn1 func(manifold uintptr, index uint32, result *Vec3)
+var jphContactManifoldGetWorldSpaceContactPointOn2 func(manifold uintptr, index uint32, result *Vec3)
+
+// --- ContactSettings ---
+var jphContactSettingsGetFriction func(settings uintptr) float32
+var jphContactSettingsSetFriction func(settings uintptr, value float32)
+var jphContactSettingsGetRestitution func(settings uintptr) float32
+var jphContactSettingsSetRestitution func(settings uintptr, value float32)
+var jphContactSettingsGetInvMassScale1 func(settings uintptr) float32
+var jphContactSettingsSetInvMassScale1 func(settings uintptr, value float32)
+var jphContactSettingsGetInvInertiaScale1 func(settings uintptr) float32
+var jphContactSettingsSetInvInertiaScale1 func(settings uintptr, value float32)
+var jphContactSettingsGetInvMassScale2 func(settings uintptr) float32
+var jphContactSettingsSetInvMassScale2 func(settings uintptr, value float32)
+var jphContactSettingsGetInvInertiaScale2 func(settings uintptr) float32
+var jphContactSettingsSetInvInertiaScale2 func(settings uintptr, value float32)
+var jphContactSettingsGetIsSensor func(settings uintptr) bool
+var jphContactSettingsSetIsSensor func(settings uintptr, sensor bool)
+var jphContactSettingsGetRelativeLinearSurfaceVelocity func(settings uintptr, result *Vec3)
+var jphContactSettingsSetRelativeLinearSurfaceVelocity func(settings uintptr, velocity *Vec3)
+var jphContactSettingsGetRelativeAngularSurfaceVelocity func(settings uintptr, result *Vec3)
+var jphContactSettingsSetRelativeAngularSurfaceVelocity func(settings uintptr, velocity *Vec3)
+
+// --- Body ---
+var jphBodyGetID func(body uintptr) uint32
+var jphBodyGetWorldSpaceSurfaceNormal func(body uintptr, subShapeID uint32, position, normal *Vec3)
+var jphBodyGetMotionProperties func(body uintptr) uintptr
+var jphBodyGetPosition func(body uintptr, result *Vec3)
+var jphBodyGetRotation func(body uintptr, result *Quat)
+var jphBodyGetCenterOfMassPosition func(body uintptr, result *Vec3)
+var jphBodyGetLinearVelocity func(body uintptr, velocity *Vec3)
+var jphBodySetLinearVelocity func(body uintptr, velocity *Vec3)
+var jphBodyGetAngularVelocity func(body uintptr, velocity *Vec3)
+var jphBodySetAngularVelocity func(body uintptr, velocity *Vec3)
+var jphBodyAddForce func(body uintptr, force *Vec3)
+var jphBodyAddTorque func(body uintptr, torque *Vec3)
+var jphBodyAddImpulse func(body uintptr, impulse *Vec3)
+var jphBodyGetShape func(body uintptr) uintptr
+var jphBodyGetMotionType func(body uintptr) int32
+var jphBodyGetObjectLayer func(body uintptr) uint32
+var jphBodyGetFriction func(body uintptr) float32
+var jphBodyGetRestitution func(body uintptr) float32
+var jphBodyGetUserData func(body uintptr) uint64
+var jphBodyIsSensor func(body uintptr) bool
+var jphBodySetIsSensor func(body uintptr, value bool)
+var jphBodyIsActive func(body uintptr) bool
+var jphBodyIsInBroadPhase func(body uintptr) bool
+var jphBodyGetBodyType func(body uintptr) int32
+var jphBodyIsStatic func(body uintptr) bool
+var jphBodyIsKinematic func(body uintptr) bool
+var jphBodyIsDynamic func(body uintptr) bool
+var jphBodyGetAllowSleeping func(body uintptr) bool
+var jphBodySetAllowSleeping func(body uintptr, allowSleeping bool)
+var jphBodyResetSleepTimer func(body uintptr)
+var jphBodyGetWorldSpaceBounds func(body uintptr, result *AABox)
+var jphBodyGetCollisionGroup func(body uintptr, result *collisionGroup)
+var jphBodySetCollisionGroup func(body uintptr, value *collisionGroup)
+
+// --- CollisionGroup ---
+
+// collisionGroup mirrors the C struct JPH_CollisionGroup.
+type collisionGroup struct {
+	GroupFilter uintptr
+	GroupID     uint32
+	SubGroupID  uint32
+}
+
+// --- GroupFilter ---
+var jphGroupFilterDestroy func(filter uintptr)
+var jphGroupFilterCanCollide func(filter uintptr, group1, group2 *collisionGroup) bool
+var jphGroupFilterTableCreate func(numSubGroups uint32) uintptr
+var jphGroupFilterTableEnableCollision func(table uintptr, subGroup1, subGroup2 uint32)
+var jphGroupFilterTableDisableCollision func(table uintptr, subGroup1, subGroup2 uint32)
+var jphGroupFilterTableIsCollisionEnabled func(table uintptr, subGroup1, subGroup2 uint32) bool
+
+// --- MotionProperties ---
+var jphMotionPropertiesGetInverseMassUnchecked func(properties uintptr) float32
+var jphMotionPropertiesGetInverseInertiaDiagonal func(properties uintptr, result *Vec3)
+var jphMotionPropertiesGetInertiaRotation func(properties uintptr, result *Quat)
+var jphMotionPropertiesGetLinearDamping func(properties uintptr) float32
+var jphMotionPropertiesSetLinearDamping func(properties uintptr, damping float32)
+var jphMotionPropertiesGetAngularDamping func(properties uintptr) float32
+var jphMotionPropertiesSetAngularDamping func(properties uintptr, damping float32)
+var jphMotionPropertiesGetAllowedDOFs func(properties uintptr) int32
+
+// --- MassProperties ---
+var jphMassPropertiesScaleToMass func(properties *MassProperties, mass float32)
 
 // --- Shapes ---
 var jphBoxShapeCreate func(halfExtent *Vec3, convexRadius float32) uintptr
@@ -66,12 +302,16 @@ var jphSphereShapeCreate func(radius float32) uintptr
 var jphSphereShapeGetRadius func(shape uintptr) float32
 var jphCapsuleShapeCreate func(halfHeight float32, radius float32) uintptr
 var jphShapeDestroy func(shape uintptr)
+var jphShapeAddRef func(shape uintptr)
+var jphShapeGetMassProperties func(shape uintptr, result *MassProperties)
 
 // --- BodyCreationSettings ---
 var jphBodyCreationSettingsCreate3 func(shape uintptr, position *Vec3, rotation *Quat, motionType int32, objectLayer uint32) uintptr
 var jphBodyCreationSettingsDestroy func(settings uintptr)
 var jphBodyCreationSettingsSetLinearVelocity func(settings uintptr, velocity *Vec3)
 var jphBodyCreationSettingsGetLinearVelocity func(settings uintptr, velocity *Vec3)
+var jphBodyCreationSettingsSetAngularVelocity func(settings uintptr, velocity *Vec3)
+var jphBodyCreationSettingsGetAngularVelocity func(settings uintptr, velocity *Vec3)
 var jphBodyCreationSettingsSetFriction func(settings uintptr, value float32)
 var jphBodyCreationSettingsGetFriction func(settings uintptr) float32
 var jphBodyCreationSettingsSetRestitution func(settings uintptr, value float32)
@@ -82,9 +322,20 @@ var jphBodyCreationSettingsSetAllowSleeping func(settings uintptr, value bool)
 var jphBodyCreationSettingsGetAllowSleeping func(settings uintptr) bool
 var jphBodyCreationSettingsSetMotionQuality func(settings uintptr, value int32)
 var jphBodyCreationSettingsGetMotionQuality func(settings uintptr) int32
+var jphBodyCreationSettingsSetCollisionGroup func(settings uintptr, value *collisionGroup)
+var jphBodyCreationSettingsGetCollisionGroup func(settings uintptr, result *collisionGroup)
+var jphBodyCreationSettingsSetOverrideMassProperties func(settings uintptr, value int32)
+var jphBodyCreationSettingsGetOverrideMassProperties func(settings uintptr) int32
+var jphBodyCreationSettingsSetMassPropertiesOverride func(settings uintptr, massProperties *MassProperties)
+var jphBodyCreationSettingsGetMassPropertiesOverride func(settings uintptr, result *MassProperties)
 
 // --- BodyInterface ---
 var jphBodyInterfaceCreateAndAddBody func(bi uintptr, settings uintptr, activation int32) uint32
+var jphBodyInterfaceCreateBody func(bi uintptr, settings uintptr) uintptr
+var jphBodyInterfaceAddBody func(bi uintptr, bodyID uint32, activation int32)
+var jphBodyInterfaceAddBodiesPrepare func(bi uintptr, ioBodies *BodyID, count int32) uintptr
+var jphBodyInterfaceAddBodiesFinalize func(bi uintptr, ioBodies *BodyID, count int32, addState uintptr, activation int32)
+var jphBodyInterfaceAddBodiesAbort func(bi uintptr, ioBodies *BodyID, count int32, addState uintptr)
 var jphBodyInterfaceRemoveAndDestroyBody func(bi uintptr, bodyID uint32)
 var jphBodyInterfaceRemoveBody func(bi uintptr, bodyID uint32)
 var jphBodyInterfaceDestroyBody func(bi uintptr, bodyID uint32)
@@ -96,6 +347,8 @@ var jphBodyInterfaceSetPosition func(bi uintptr, bodyID uint32, position *Vec3,
 var jphBodyInterfaceGetPosition func(bi uintptr, bodyID uint32, result *Vec3)
 var jphBodyInterfaceSetRotation func(bi uintptr, bodyID uint32, rotation *Quat, activation int32)
 var jphBodyInterfaceGetRotation func(bi uintptr, bodyID uint32, result *Quat)
+var jphBodyInterfaceGetPositionAndRotation func(bi uintptr, bodyID uint32, position *Vec3, rotation *Quat)
+var jphBodyInterfaceGetLinearAndAngularVelocity func(bi uintptr, bodyID uint32, linearVelocity *Vec3, angularVelocity *Vec3)
 var jphBodyInterfaceActivateBody func(bi uintptr, bodyID uint32)
 var jphBodyInterfaceDeactivateBody func(bi uintptr, bodyID uint32)
 var jphBodyInterfaceIsActive func(bi uintptr, bodyID uint32) bool
@@ -103,6 +356,22 @@ var jphBodyInterfaceAddForce func(bi uintptr, bodyID uint32, force *Vec3)
 var jphBodyInterfaceAddImpulse func(bi uintptr, bodyID uint32, impulse *Vec3)
 var jphBodyInterfaceAddImpulse2 func(bi uintptr, bodyID uint32, impulse *Vec3, point *Vec3)
 var jphBodyInterfaceAddAngularImpulse func(bi uintptr, bodyID uint32, angularImpulse *Vec3)
+var jphBodyInterfaceAddForce2 func(bi uintptr, bodyID uint32, force *Vec3, point *Vec3)
+var jphBodyInterfaceAddTorque func(bi uintptr, bodyID uint32, torque *Vec3)
+var jphBodyInterfaceSetAngularVelocity func(bi uintptr, bodyID uint32, angularVelocity *Vec3)
+var jphBodyInterfaceGetAngularVelocity func(bi uintptr, bodyID uint32, angularVelocity *Vec3)
+var jphBodyInterfaceSetLinearAndAngularVelocity func(bi uintptr, bodyID uint32, linearVelocity *Vec3, angularVelocity *Vec3)
+var jphBodyInterfaceAddLinearVelocity func(bi uintptr, bodyID uint32, linearVelocity *Vec3)
+var jphBodyInterfaceGetPointVelocity func(bi uintptr, bodyID uint32, point *Vec3, velocity *Vec3)
+var jphBodyInterfaceSetPositionAndRotation func(bi uintptr, bodyID uint32, position *Vec3, rotation *Quat, activation int32)
+var jphBodyInterfaceGetObjectLayer func(bi uintptr, bodyID uint32) uint32
+var jphBodyInterfaceSetObjectLayer func(bi uintptr, bodyID uint32, layer uint32)
+var jphBodyInterfaceSetUserData func(bi uintptr, bodyID uint32, userData uint64)
+var jphBodyInterfaceGetUserData func(bi uintptr, bodyID uint32) uint64
+var jphBodyInterfaceGetShape func(bi uintptr, bodyID uint32) uintptr
+var jphBodyInterfaceSetShape func(bi uintptr, bodyID uint32, shape uintptr, updateMassProperties bool, activation int32)
+var jphBodyInterfaceMoveKinematic func(bi uintptr, bodyID uint32, targetPosition *Vec3, targetRotation *Quat, deltaTime float32)
+var jphBodyInterfaceSetPositionRotationAndVelocity func(bi uintptr, bodyID uint32, position *Vec3, rotation *Quat, linearVelocity *Vec3, angularVelocity *Vec3)
 var jphBodyInterfaceSetFriction func(bi uintptr, bodyID uint32, friction float32)
 var jphBodyInterfaceGetFriction func(bi uintptr, bodyID uint32) float32
 var jphBodyInterfaceSetRestitution func(bi uintptr, bodyID uint32, restitution float32)
@@ -111,3 +380,4 @@ var jphBodyInterfaceSetGravityFactor func(bi uintptr, bodyID uint32, value float
 var jphBodyInterfaceGetGravityFactor func(bi uintptr, bodyID uint32) float32
 var jphBodyInterfaceGetMotionType func(bi uintptr, bodyID uint32) int32
 var jphBodyInterfaceSetMotionType func(bi uintptr, bodyID uint32, motionType int32, activation int32)
+var jphBodyInterfaceGetInverseInertia func(bi uintptr, bodyID uint32, result *Matrix4x4)
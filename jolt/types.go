@@ -21,14 +21,62 @@ type Quat struct {
 	X, Y, Z, W float32
 }
 
+// AABox is an axis-aligned bounding box.
+type AABox struct {
+	Min, Max Vec3
+}
+
+// Matrix4x4 represents a 4x4 single-precision matrix.
+// Field layout matches joltc's JPH_Matrix4x4, where M<row><column> names
+// each element.
+type Matrix4x4 struct {
+	M11, M12, M13, M14 float32
+	M21, M22, M23, M24 float32
+	M31, M32, M33, M34 float32
+	M41, M42, M43, M44 float32
+}
+
+// Matrix4x4Identity returns the identity matrix.
+func Matrix4x4Identity() Matrix4x4 {
+	return Matrix4x4{M11: 1, M22: 1, M33: 1, M44: 1}
+}
+
 // QuatIdentity returns the identity quaternion (no rotation).
 func QuatIdentity() Quat {
 	return Quat{X: 0, Y: 0, Z: 0, W: 1}
 }
 
 // BodyID is an opaque identifier for a physics body.
+//
+// It packs a body index (the low 23 bits) and a sequence number (the high 8
+// bits). The sequence number is incremented each time an index is reused, so
+// a BodyID kept after its body was destroyed does not address the new body.
 type BodyID uint32
 
+const (
+	// BodyIDInvalid is a BodyID that never addresses a body. It is returned
+	// when a body could not be created.
+	BodyIDInvalid BodyID = 0xFFFFFFFF
+
+	bodyIDMaxIndex = 0x7FFFFF
+)
+
+// Index returns the index of the body in the body manager.
+func (id BodyID) Index() uint32 {
+	return uint32(id) & bodyIDMaxIndex
+}
+
+// SequenceNumber returns the sequence number of the body, which
+// distinguishes bodies that reuse the same index.
+func (id BodyID) SequenceNumber() uint8 {
+	return uint8(id >> 24)
+}
+
+// IsInvalid returns whether id is BodyIDInvalid.
+func (id BodyID) IsInvalid() bool {
+	return id == BodyIDInvalid
+}
+
 // ObjectLayer identifies which collision layer an object belongs to.
 type ObjectLayer uint32
 
@@ -44,6 +92,14 @@ const (
 	MotionTypeDynamic   MotionType = 2
 )
 
+// BodyType distinguishes rigid bodies from soft bodies.
+type BodyType int32
+
+const (
+	BodyTypeRigid BodyType = 0
+	BodyTypeSoft  BodyType = 1
+)
+
 // Activation specifies whether a body should be activated when added or modified.
 type Activation int32
 
@@ -83,3 +139,69 @@ const (
 	AllowedDOFsRotationZ    AllowedDOFs = 0b100000
 	AllowedDOFsPlane2D      AllowedDOFs = AllowedDOFsTranslationX | AllowedDOFsTranslationY | AllowedDOFsRotationZ
 )
+
+// OverrideMassProperties controls how a body's mass and inertia are determined
+// when it is created.
+type OverrideMassProperties int32
+
+const (
+	// OverrideMassPropertiesCalculateMassAndInertia derives mass and inertia
+	// from the shape's density and volume.
+	OverrideMassPropertiesCalculateMassAndInertia OverrideMassProperties = 0
+	// OverrideMassPropertiesCalculateInertia uses the provided mass and scales
+	// the shape's inertia to match it.
+	OverrideMassPropertiesCalculateInertia OverrideMassProperties = 1
+	// OverrideMassPropertiesMassAndInertiaProvided uses the provided mass and
+	// inertia as-is.
+	OverrideMassPropertiesMassAndInertiaProvided OverrideMassProperties = 2
+)
+
+// SubShapeID identifies a sub shape within a compound or mesh shape, such as
+// a triangle of a mesh or a child of a compound. Its bits are a path through
+// the shape hierarchy and are only meaningful together with the body's shape.
+type SubShapeID uint32
+
+// ValidateResult is returned by ContactListener.OnContactValidate to decide
+// whether a contact is created.
+type ValidateResult int32
+
+const (
+	// ValidateResultAcceptAllContacts accepts this contact and all further
+	// contacts between the two bodies in this step, skipping further
+	// validation calls for the pair.
+	ValidateResultAcceptAllContacts ValidateResult = 0
+	// ValidateResultAcceptContact accepts this contact only.
+	ValidateResultAcceptContact ValidateResult = 1
+	// ValidateResultRejectContact rejects this contact only.
+	ValidateResultRejectContact ValidateResult = 2
+	// ValidateResultRejectAllContacts rejects this contact and all further
+	// contacts between the two bodies in this step.
+	ValidateResultRejectAllContacts ValidateResult = 3
+)
+
+// BackFaceMode controls whether a query reports hits on the back side of
+// faces.
+type BackFaceMode int32
+
+const (
+	// BackFaceModeIgnoreBackFaces skips faces whose normal points away from
+	// the query.
+	BackFaceModeIgnoreBackFaces BackFaceMode = 0
+	// BackFaceModeCollideWithBackFaces reports hits on both sides of faces.
+	BackFaceModeCollideWithBackFaces BackFaceMode = 1
+)
+
+// CollisionCollectorType selects which hits a query collects.
+type CollisionCollectorType int32
+
+const (
+	// CollisionCollectorAllHit collects every hit in no particular order.
+	CollisionCollectorAllHit CollisionCollectorType = 0
+	// CollisionCollectorAllHitSorted collects every hit, closest first.
+	CollisionCollectorAllHitSorted CollisionCollectorType = 1
+	// CollisionCollectorClosestHit collects only the closest hit, skipping
+	// bodies that are further away than the closest hit so far.
+	CollisionCollectorClosestHit CollisionCollectorType = 2
+	// CollisionCollectorAnyHit stops at the first hit found.
+	CollisionCollectorAnyHit CollisionCollectorType = 3
+)